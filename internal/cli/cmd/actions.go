@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// actionsCmd groups commands inspecting GitHub Actions.
+var actionsCmd = &cobra.Command{
+	Use:   "actions",
+	Short: "Inspect GitHub Actions",
+	Long:  `Commands for inspecting GitHub Actions from the command line, using the same logic as the MCP tools.`,
+}
+
+// actionsDiffFlags holds the flags of the actions diff command.
+var actionsDiffFlags struct {
+	json bool
+}
+
+// actionsDiffCmd compares the interface of an action between two versions.
+var actionsDiffCmd = &cobra.Command{
+	Use:   "diff <action> <from-version> [to-version]",
+	Short: "Show how an action's inputs, outputs and runtime changed between two versions",
+	Long: `Compares the action.yml of an action between two versions and reports added,
+removed and renamed inputs, changed defaults, newly required inputs, new
+deprecation messages, added and removed outputs and runtime changes.
+
+The action is given without version, e.g. docker/build-push-action or
+github/codeql-action/init. Versions can be tags, branches, commit SHAs or
+version specs such as latest or ^6. If to-version is omitted, the latest
+stable release is used.
+
+Example:
+  github-actions-utils-cli actions diff docker/build-push-action v5 v6`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runActionsDiff,
+}
+
+// actionsAuditFlags holds the flags of the actions audit command.
+var actionsAuditFlags struct {
+	json bool
+}
+
+// actionsAuditCmd reports deprecations in an action.
+var actionsAuditCmd = &cobra.Command{
+	Use:   "audit <action-ref>",
+	Short: "Report deprecated runtimes, inputs and workflow commands used by an action",
+	Long: `Audits the action.yml of an action for deprecations: a deprecated runtime
+(runs.using node12, node16 or node20), inputs the action marks as deprecated,
+and composite steps using the deprecated ::set-output, ::save-state, ::set-env
+or ::add-path workflow commands.
+
+If any deprecation is found, the newest stable release is audited as well and
+suggested if it fixes them.
+
+Example:
+  github-actions-utils-cli actions audit actions/setup-node@v3`,
+	Args: cobra.ExactArgs(1),
+	RunE: runActionsAudit,
+}
+
+func init() {
+	actionsDiffCmd.Flags().BoolVar(&actionsDiffFlags.json, "json", false, "Print the diff as JSON")
+	actionsCmd.AddCommand(actionsDiffCmd)
+	actionsAuditCmd.Flags().BoolVar(&actionsAuditFlags.json, "json", false, "Print the audit as JSON")
+	actionsCmd.AddCommand(actionsAuditCmd)
+	rootCmd.AddCommand(actionsCmd)
+}
+
+func runActionsDiff(cmd *cobra.Command, args []string) error {
+	toVersion := github.VersionLatest
+	if len(args) == 3 {
+		toVersion = args[2]
+	}
+
+	actionsService, err := newActionsService()
+	if err != nil {
+		return err
+	}
+
+	diff, err := actionsService.DiffActionVersions(cmd.Context(), args[0], args[1], toVersion)
+	if err != nil {
+		return err
+	}
+
+	if actionsDiffFlags.json {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), diff.String())
+	return err
+}
+
+func runActionsAudit(cmd *cobra.Command, args []string) error {
+	actionsService, err := newActionsService()
+	if err != nil {
+		return err
+	}
+
+	audit, err := actionsService.AuditAction(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	if actionsAuditFlags.json {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(audit)
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), audit.String())
+	return err
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// githubFlags holds the global flags configuring access to GitHub.
+var githubFlags struct {
+	token    string
+	host     string
+	cacheDir string
+	cacheTTL time.Duration
+	noCache  bool
+
+	requestTimeout time.Duration
+	timeout        time.Duration
+	maxRetries     int
+
+	workspace string
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&githubFlags.token, "github-token", "",
+		"GitHub token used to authenticate requests (defaults to $GITHUB_TOKEN, $GH_TOKEN or the token stored by the gh CLI)")
+	rootCmd.PersistentFlags().StringVar(&githubFlags.host, "github-host", "",
+		"GitHub host or URL, e.g. ghe.example.com for GitHub Enterprise Server (defaults to $GITHUB_SERVER_URL or github.com)")
+	rootCmd.PersistentFlags().StringVar(&githubFlags.cacheDir, "cache-dir", "",
+		"Directory used to cache files fetched from GitHub (defaults to a directory in the user cache directory)")
+	rootCmd.PersistentFlags().DurationVar(&githubFlags.cacheTTL, "cache-ttl", github.DefaultCacheTTL,
+		"Time after which cached files of tags and branches are revalidated with GitHub (files at commit SHAs never expire)")
+	rootCmd.PersistentFlags().BoolVar(&githubFlags.noCache, "no-cache", false,
+		"Disable the on-disk cache and always fetch files from GitHub")
+	rootCmd.PersistentFlags().DurationVar(&githubFlags.requestTimeout, "request-timeout", github.DefaultRequestTimeout,
+		"Maximum duration of a single HTTP request to GitHub")
+	rootCmd.PersistentFlags().DurationVar(&githubFlags.timeout, "timeout", github.DefaultTimeout,
+		"Maximum duration of an operation, e.g. fetching an action, including all requests it sends")
+	rootCmd.PersistentFlags().IntVar(&githubFlags.maxRetries, "max-retries", github.DefaultMaxRetries,
+		"Number of times a request to GitHub failing with a server error or an exceeded rate limit is retried (0 disables retries)")
+	rootCmd.PersistentFlags().StringVar(&githubFlags.workspace, "workspace", "",
+		"Directory local references such as ./.github/actions/setup are resolved against, usually the root of a checked-out repository (the mcp command defaults to the roots provided by the client)")
+}
+
+// newActionsService creates an ActionsService configured from the global GitHub flags.
+// The GitHub instance is discovered from the flags or the environment, and the token
+// from the flag, the environment or the gh CLI configuration.
+func newActionsService() (*github.ActionsService, error) {
+	endpoints, err := github.DiscoverEndpoints(githubFlags.host)
+	if err != nil {
+		return nil, err
+	}
+	token, _ := github.DiscoverToken(githubFlags.token, endpoints.Host())
+
+	cache, err := newCache()
+	if err != nil {
+		return nil, err
+	}
+
+	var workspace string
+	if githubFlags.workspace != "" {
+		if workspace, err = filepath.Abs(githubFlags.workspace); err != nil {
+			return nil, fmt.Errorf("invalid workspace: %w", err)
+		}
+	}
+
+	return github.NewActionsService(&github.ActionsServiceOptions{
+		Token:          token,
+		Endpoints:      endpoints,
+		Cache:          cache,
+		RequestTimeout: githubFlags.requestTimeout,
+		Timeout:        githubFlags.timeout,
+		MaxRetries:     maxRetries(),
+		Workspace:      workspace,
+	}), nil
+}
+
+// newCache creates the on-disk cache configured by the global flags.
+// It returns nil if caching is disabled. If no cache directory was given explicitly
+// and the default one is unavailable, caching is disabled instead of failing.
+func newCache() (*github.Cache, error) {
+	if githubFlags.noCache {
+		return nil, nil
+	}
+
+	if githubFlags.cacheDir != "" {
+		return github.NewCache(githubFlags.cacheDir, githubFlags.cacheTTL)
+	}
+
+	dir, err := github.DefaultCacheDir()
+	if err != nil {
+		return nil, nil
+	}
+	cache, err := github.NewCache(dir, githubFlags.cacheTTL)
+	if err != nil {
+		return nil, nil
+	}
+	return cache, nil
+}
+
+// maxRetries returns the number of retries configured by the global flags,
+// translated to ActionsServiceOptions.MaxRetries, where zero selects the default.
+func maxRetries() int {
+	if githubFlags.maxRetries <= 0 {
+		return -1
+	}
+	return githubFlags.maxRetries
+}
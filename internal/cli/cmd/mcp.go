@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 
 	mcp_sdk "github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/spf13/cobra"
 	"github.com/techprimate/github-actions-utils-cli/internal/cli/mcp"
-	"github.com/techprimate/github-actions-utils-cli/internal/github"
 )
 
 var MCPCmd = &cobra.Command{
@@ -15,12 +18,50 @@ var MCPCmd = &cobra.Command{
 	Short: "Run MCP server for agent integration",
 	Long: `Runs an MCP (Model Context Protocol) server that exposes GitHub Actions utilities as tools.
 
-The server communicates over stdin/stdout and provides this tool:
+By default, the server communicates over stdin/stdout. With --transport http,
+it listens on --addr instead and serves the streamable HTTP transport at /mcp,
+the legacy SSE transport at /sse and a health check at /healthz. It shuts down
+gracefully on SIGTERM, so it can run as a long-lived service, e.g. in Docker.
+Clients can be required to send a bearer token, either an API key listed in
+--auth-api-keys, optionally restricted to some tools, or a JWT signed by a key
+in --auth-jwks, granted tools by scopes such as mcp:tool:get_readme.
+
+The server provides these tools:
   - get_action_parameters: Fetch and parse GitHub Action action.yml files
+  - get_readme: Fetch README files from GitHub repositories
+  - list_action_versions: List an action's versions and resolve specs like latest or ^4
+  - diff_action_versions: Compare an action's inputs, outputs and runtime between two versions
+  - pin_actions: Pin the actions used by a workflow to full commit SHAs
+  - inventory_workflow_actions: List the actions and reusable workflows referenced by a workflow
+  - validate_workflow: Check the inputs passed to actions in a workflow
+  - get_reusable_workflow_parameters: Fetch the inputs, secrets and outputs of a reusable workflow
+  - get_action_dependency_tree: Recursively resolve the actions used by composite actions
+  - audit_action: Report deprecated runtimes, inputs and workflow commands used by an action
+  - get_repository_file: Fetch any file from a GitHub repository
+  - list_repository_directory: List the files and subdirectories of a directory in a repository
+
+Action definitions and READMEs are also available as resources, e.g.
+gha://action/actions/checkout@v5 and gha://readme/actions/checkout@main.
+The prompts write_step_for_action, upgrade_action and explain_workflow embed
+the same data into ready-made requests. Clients can complete the action
+references passed to prompts and resources: owners from --completion-owners,
+repositories via GitHub's search, and versions from the repository's tags.
 
 This allows AI agents to programmatically retrieve information about GitHub Actions,
 including their inputs, outputs, and configuration.
 
+Requests to GitHub are authenticated with the token passed via --github-token,
+or found in $GITHUB_TOKEN, $GH_TOKEN or the gh CLI configuration. A token is
+required for private and internal actions and raises the rate limits.
+
+Local actions and workflows such as ./.github/actions/setup are read from the
+directory passed via --workspace, or else, over stdio, from the first root
+provided by the client. Files outside of the workspace cannot be read.
+
+To use GitHub Enterprise Server, pass its host via --github-host or set
+$GITHUB_SERVER_URL (and optionally $GITHUB_API_URL, which is ignored if
+--github-host is given).
+
 Example MCP client configuration:
 {
   "mcpServers": {
@@ -29,34 +70,122 @@ Example MCP client configuration:
       "args": ["mcp"]
     }
   }
+}
+
+Example configuration for a server running with --transport http:
+{
+  "mcpServers": {
+    "github-actions-utils": {
+      "url": "http://localhost:8080/mcp"
+    }
+  }
 }`,
 	RunE: runMCP,
 }
 
+// mcpFlags holds the flags of the mcp command.
+var mcpFlags struct {
+	transport      string
+	addr           string
+	apiKeys        string
+	jwks           string
+	jwtIssuer      string
+	jwtAudience    string
+	jwtScopePrefix string
+
+	completionOwners []string
+}
+
 func init() {
+	MCPCmd.Flags().StringVar(&mcpFlags.transport, "transport", mcp.TransportStdio, "Transport to serve the MCP server over: stdio or http (streamable HTTP and SSE)")
+	MCPCmd.Flags().StringVar(&mcpFlags.addr, "addr", ":8080", "Address to listen on with --transport http")
+	MCPCmd.Flags().StringVar(&mcpFlags.apiKeys, "auth-api-keys", "", "YAML file with the API keys accepted as bearer tokens with --transport http")
+	MCPCmd.Flags().StringVar(&mcpFlags.jwks, "auth-jwks", "", "JWKS file with the keys verifying JWT bearer tokens with --transport http")
+	MCPCmd.Flags().StringVar(&mcpFlags.jwtIssuer, "auth-jwt-issuer", "", "Required issuer (iss) of JWT bearer tokens")
+	MCPCmd.Flags().StringVar(&mcpFlags.jwtAudience, "auth-jwt-audience", "", "Required audience (aud) of JWT bearer tokens")
+	MCPCmd.Flags().StringVar(&mcpFlags.jwtScopePrefix, "auth-jwt-tool-scope-prefix", mcp.DefaultToolScopePrefix, "Prefix of the JWT scopes granting access to tools, followed by the tool name or * for all tools")
+	MCPCmd.Flags().StringSliceVar(&mcpFlags.completionOwners, "completion-owners", mcp.DefaultCompletionOwners, "Owners offered when completing action and repository references, e.g. your organizations")
 	rootCmd.AddCommand(MCPCmd)
 }
 
 func runMCP(cmd *cobra.Command, args []string) error {
-	// MCP uses stdio for JSON-RPC, so we need to silence the logger
-	// to avoid interfering with the protocol
-	silentLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if mcpFlags.transport != mcp.TransportStdio && mcpFlags.transport != mcp.TransportHTTP {
+		return fmt.Errorf("invalid transport '%s': expected %s or %s", mcpFlags.transport, mcp.TransportStdio, mcp.TransportHTTP)
+	}
 
-	// Create GitHub Actions service
-	actionsService := github.NewActionsService()
+	// Stop serving on Ctrl+C and SIGTERM, e.g. when the container is stopped
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Create MCP server wrapper with silent logger
-	mcpSrv := mcp.NewMCPServer(actionsService, silentLogger)
+	// Create GitHub Actions service, authenticated if a token is available
+	actionsService, err := newActionsService()
+	if err != nil {
+		return err
+	}
 
-	// Create go-sdk MCP server
-	server := mcp_sdk.NewServer(&mcp_sdk.Implementation{
+	impl := &mcp_sdk.Implementation{
 		Name:    "github-actions-utils",
 		Version: version,
-	}, nil)
+	}
+
+	if mcpFlags.transport == mcp.TransportHTTP {
+		authenticator, err := newAuthenticator()
+		if err != nil {
+			return err
+		}
 
-	// Register all tools
-	mcpSrv.RegisterTools(server)
+		// Log to stderr, as the protocol does not use stdio
+		logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+		if authenticator == nil {
+			logger.Warn("requests are not authenticated; use --auth-api-keys or --auth-jwks to require a bearer token")
+		}
+		mcpServer := mcp.NewMCPServer(actionsService, logger)
+		mcpServer.SetCompletionOwners(mcpFlags.completionOwners)
+		return mcpServer.ListenAndServe(ctx, mcpFlags.addr, impl, &mcp.HTTPOptions{
+			Authenticator: authenticator,
+		})
+	}
+	if mcpFlags.apiKeys != "" || mcpFlags.jwks != "" {
+		return fmt.Errorf("--auth-api-keys and --auth-jwks require --transport %s", mcp.TransportHTTP)
+	}
+
+	// MCP uses stdio for JSON-RPC, so we need to silence the logger
+	// to avoid interfering with the protocol
+	silentLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// Create go-sdk MCP server with all tools, resolving local references against the client's roots
+	mcpServer := mcp.NewMCPServer(actionsService, silentLogger)
+	mcpServer.SetCompletionOwners(mcpFlags.completionOwners)
+	server := mcpServer.NewServer(impl, mcp.TransportStdio)
 
 	// Run server on stdio (logging disabled to keep stdio clean for JSON-RPC)
-	return server.Run(cmd.Context(), &mcp_sdk.StdioTransport{})
+	return server.Run(ctx, &mcp_sdk.StdioTransport{})
+}
+
+// newAuthenticator creates the authenticator of the HTTP transport from the --auth-* flags,
+// or returns nil if no credentials are configured.
+func newAuthenticator() (mcp.Authenticator, error) {
+	var authenticators mcp.Authenticators
+	if mcpFlags.apiKeys != "" {
+		apiKeys, err := mcp.LoadAPIKeys(mcpFlags.apiKeys)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, apiKeys)
+	}
+	if mcpFlags.jwks != "" {
+		jwt, err := mcp.LoadJWKS(mcpFlags.jwks, mcp.JWTOptions{
+			Issuer:          mcpFlags.jwtIssuer,
+			Audience:        mcpFlags.jwtAudience,
+			ToolScopePrefix: mcpFlags.jwtScopePrefix,
+		})
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, jwt)
+	}
+	if len(authenticators) == 0 {
+		return nil, nil
+	}
+	return authenticators, nil
 }
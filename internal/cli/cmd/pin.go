@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// pinFlags holds the flags of the pin command.
+var pinFlags struct {
+	dryRun bool
+}
+
+// pinCmd pins the actions used by workflow files to full commit SHAs.
+var pinCmd = &cobra.Command{
+	Use:   "pin <workflow-file>...",
+	Short: "Pin the actions used by workflow files to full commit SHAs",
+	Long: `Rewrites every action and reusable workflow referenced with "uses:" in the given
+workflow files (or composite action.yml files) to a full commit SHA, noting the
+resolved version in a comment:
+
+  uses: actions/checkout@v4
+becomes
+  uses: actions/checkout@11bd71901bbe5b1630ceea73d27597364c9af683 # v4.2.1
+
+Tags are resolved through the GitHub API, so moving tags such as v4 are noted with
+the exact release they point to. Branches are pinned to their current commit.
+Local actions, Docker images and references already pinned to a SHA are left
+unchanged, as are the formatting and comments of the files.
+
+With --dry-run, the changes are printed as a unified diff instead of being written.
+The command fails if any reference could not be resolved.
+
+Example:
+  github-actions-utils-cli pin --dry-run .github/workflows/*.yml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runPin,
+}
+
+func init() {
+	pinCmd.Flags().BoolVar(&pinFlags.dryRun, "dry-run", false, "Print the changes as a unified diff instead of writing them")
+	rootCmd.AddCommand(pinCmd)
+}
+
+func runPin(cmd *cobra.Command, args []string) error {
+	actionsService, err := newActionsService()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, path := range args {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		result, err := actionsService.PinWorkflow(cmd.Context(), path, content)
+		if err != nil {
+			return fmt.Errorf("failed to pin %s: %w", path, err)
+		}
+
+		for _, action := range result.Actions {
+			switch action.Status {
+			case github.PinStatusFailed:
+				failed++
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s:%d: failed to pin %s: %s\n", path, action.Line, action.Original, action.Reason)
+			case github.PinStatusPinned:
+				if !pinFlags.dryRun {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: pinned %s to %s (%s)\n", path, action.Line, action.Original, action.Pinned, action.Version)
+				}
+			}
+		}
+
+		if !result.Changed() {
+			continue
+		}
+		if pinFlags.dryRun {
+			fmt.Fprint(cmd.OutOrStdout(), result.Diff)
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(result.Content), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d reference(s) could not be pinned", failed)
+	}
+	return nil
+}
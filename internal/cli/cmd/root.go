@@ -4,6 +4,10 @@
 // Command Structure:
 //   - root: Base command with global flags
 //   - mcp: Run MCP server for agent integration
+//   - actions diff: Compare an action's interface between two versions
+//   - actions audit: Report deprecations used by an action
+//   - pin: Pin the actions used by workflow files to commit SHAs
+//   - validate: Validate the inputs passed to actions in workflow files
 package cmd
 
 import (
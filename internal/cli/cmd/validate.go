@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/techprimate/github-actions-utils-cli/internal/workflow"
+)
+
+// validateFlags holds the flags of the validate command.
+var validateFlags struct {
+	json bool
+}
+
+// validateCmd validates the action inputs used by workflow files.
+var validateCmd = &cobra.Command{
+	Use:   "validate <workflow-file>...",
+	Short: "Validate the inputs passed to actions in workflow files",
+	Long: `Checks the "with:" inputs of every action step in the given workflow files against
+the inputs declared in the action's action.yml, and reports:
+
+  - unknown inputs, with a suggestion for the input that was most likely meant
+  - required inputs without default that are not set
+  - inputs the action marks as deprecated
+  - references to step outputs (steps.<id>.outputs.<name>) the action does not declare
+
+Problems are printed as "file:line:column: severity: message". Local actions
+(./...) are read from the directory passed via --workspace and only checked if it is
+set. Docker images and reusable workflows are not checked. The command fails if any
+error was found.
+
+Example:
+  github-actions-utils-cli validate .github/workflows/*.yml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runValidate,
+}
+
+func init() {
+	validateCmd.Flags().BoolVar(&validateFlags.json, "json", false, "Print the findings as JSON, keyed by file")
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	actionsService, err := newActionsService()
+	if err != nil {
+		return err
+	}
+
+	results := map[string]*workflow.ValidationResult{}
+	hasErrors := false
+	for _, path := range args {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		parsed, err := workflow.Parse(content)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		result, err := workflow.Validate(cmd.Context(), actionsService, parsed)
+		if err != nil {
+			return fmt.Errorf("failed to validate %s: %w", path, err)
+		}
+		results[path] = result
+		hasErrors = hasErrors || result.HasErrors()
+
+		if validateFlags.json {
+			continue
+		}
+		for _, finding := range result.Findings {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s:%s\n", path, finding.String())
+		}
+	}
+
+	if validateFlags.json {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			return err
+		}
+	}
+
+	if hasErrors {
+		return fmt.Errorf("validation failed")
+	}
+	return nil
+}
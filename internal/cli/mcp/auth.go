@@ -0,0 +1,278 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// Principal is the client authenticated by an Authenticator, e.g. the name of an API key
+// or the subject of a bearer token.
+type Principal struct {
+	Name string
+	// Tools are the tools the principal may call. All tools are allowed if nil, and none if empty.
+	Tools []string
+	// Scopes are the OAuth2 scopes granted to a bearer token.
+	Scopes []string
+	// Expiration is the time the credential expires, zero if it does not expire.
+	Expiration time.Time
+}
+
+// AllowsTool reports whether the principal may call the tool.
+func (p *Principal) AllowsTool(name string) bool {
+	return p.Tools == nil || slices.Contains(p.Tools, name)
+}
+
+// principalKey is the context key of the authenticated Principal.
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated principal.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal a request was authenticated as,
+// or nil if the server does not require authentication.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// An Authenticator verifies the bearer token sent with an HTTP request and returns the principal
+// it belongs to. It returns an error wrapping auth.ErrInvalidToken if the token is not valid.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// Authenticators tries each authenticator in turn, e.g. API keys and then JWTs,
+// and returns the first principal a token is valid for.
+type Authenticators []Authenticator
+
+// Authenticate implements Authenticator.
+func (a Authenticators) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	err := fmt.Errorf("%w: no authenticator configured", auth.ErrInvalidToken)
+	for _, authenticator := range a {
+		var principal *Principal
+		principal, err = authenticator.Authenticate(ctx, token)
+		if err == nil {
+			return principal, nil
+		}
+		if !errors.Is(err, auth.ErrInvalidToken) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// requireAuth wraps an HTTP handler to reject requests without a valid bearer token
+// ("Authorization: Bearer <token>") with 401 Unauthorized. The principal of the token is
+// passed on to tool handlers (see principalMiddleware).
+func requireAuth(authenticator Authenticator, handler http.Handler) http.Handler {
+	verifier := func(ctx context.Context, token string, req *http.Request) (*auth.TokenInfo, error) {
+		principal, err := authenticator.Authenticate(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		expiration := principal.Expiration
+		if expiration.IsZero() {
+			// The SDK rejects tokens without expiration; API keys are valid as long as they are configured
+			expiration = time.Now().Add(time.Hour)
+		}
+		return &auth.TokenInfo{
+			Scopes:     principal.Scopes,
+			Expiration: expiration,
+			Extra:      map[string]any{principalKeyName: principal},
+		}, nil
+	}
+	return auth.RequireBearerToken(verifier, nil)(handler)
+}
+
+// principalKeyName is the key of the authenticated Principal in auth.TokenInfo.Extra.
+const principalKeyName = "principal"
+
+// principalFromTokenInfo returns the principal stored in the token info by requireAuth, if any.
+func principalFromTokenInfo(tokenInfo *auth.TokenInfo) *Principal {
+	if tokenInfo == nil {
+		return nil
+	}
+	principal, _ := tokenInfo.Extra[principalKeyName].(*Principal)
+	return principal
+}
+
+// principalMiddleware passes the principal a request was authenticated as on to the handlers
+// in their context, and restricts it to the tools it may call: other tools are not listed,
+// and calling them fails with ErrorCodeForbidden. Resources are restricted like the tool
+// returning the same information (see resourceTools), and prompts like the tool returning the
+// data they embed (see promptTools), including the completion of their arguments.
+//
+// Requests over streamable HTTP carry the principal of their own bearer token. The legacy SSE
+// transport does not pass on tokens, so its sessions use the principal which opened them.
+func (m *MCPServer) principalMiddleware(sessionPrincipal *Principal) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			principal := sessionPrincipal
+			if extra := req.GetExtra(); extra != nil && extra.TokenInfo != nil {
+				principal = principalFromTokenInfo(extra.TokenInfo)
+			}
+			if principal == nil {
+				return next(ctx, method, req)
+			}
+			ctx = WithPrincipal(ctx, principal)
+
+			switch params := req.GetParams().(type) {
+			case *mcp.CallToolParamsRaw:
+				m.logger.Info("tool call", "principal", principal.Name, "tool", params.Name)
+				if !principal.AllowsTool(params.Name) {
+					return toolError(fmt.Errorf("%w: '%s' may not call tool '%s'", errForbidden, principal.Name, params.Name)), nil
+				}
+			case *mcp.ListToolsParams:
+				result, err := next(ctx, method, req)
+				if tools, ok := result.(*mcp.ListToolsResult); ok && err == nil {
+					tools.Tools = slices.DeleteFunc(tools.Tools, func(tool *mcp.Tool) bool {
+						return !principal.AllowsTool(tool.Name)
+					})
+				}
+				return result, err
+			case *mcp.ReadResourceParams:
+				if tool := resourceTool(params.URI); tool != "" && !principal.AllowsTool(tool) {
+					return nil, fmt.Errorf("%w: '%s' may not read %s", errForbidden, principal.Name, params.URI)
+				}
+			case *mcp.ListResourceTemplatesParams:
+				result, err := next(ctx, method, req)
+				if templates, ok := result.(*mcp.ListResourceTemplatesResult); ok && err == nil {
+					templates.ResourceTemplates = slices.DeleteFunc(templates.ResourceTemplates, func(template *mcp.ResourceTemplate) bool {
+						tool := resourceTool(template.URITemplate)
+						return tool != "" && !principal.AllowsTool(tool)
+					})
+				}
+				return result, err
+			case *mcp.GetPromptParams:
+				if tool := promptTools[params.Name]; tool != "" && !principal.AllowsTool(tool) {
+					return nil, fmt.Errorf("%w: '%s' may not use prompt '%s'", errForbidden, principal.Name, params.Name)
+				}
+			case *mcp.CompleteParams:
+				if params.Ref == nil {
+					return nil, invalidParams("ref is required")
+				}
+				tool := promptTools[params.Ref.Name]
+				if params.Ref.Type == "ref/resource" {
+					tool = resourceTool(params.Ref.URI)
+				}
+				if tool != "" && !principal.AllowsTool(tool) {
+					return nil, fmt.Errorf("%w: '%s' may not complete arguments of %s", errForbidden, principal.Name, params.Ref.Type)
+				}
+			case *mcp.ListPromptsParams:
+				result, err := next(ctx, method, req)
+				if prompts, ok := result.(*mcp.ListPromptsResult); ok && err == nil {
+					prompts.Prompts = slices.DeleteFunc(prompts.Prompts, func(prompt *mcp.Prompt) bool {
+						tool := promptTools[prompt.Name]
+						return tool != "" && !principal.AllowsTool(tool)
+					})
+				}
+				return result, err
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// apiKeysFile is the format of the API key file (see LoadAPIKeys).
+type apiKeysFile struct {
+	Keys []struct {
+		Name   string   `yaml:"name"`
+		Key    string   `yaml:"key"`
+		SHA256 string   `yaml:"sha256"`
+		Tools  []string `yaml:"tools"`
+	} `yaml:"keys"`
+}
+
+// apiKey is a configured API key, identified by the SHA-256 hash of the key.
+type apiKey struct {
+	hash      [sha256.Size]byte
+	principal Principal
+}
+
+// APIKeyAuthenticator authenticates static API keys sent as bearer tokens.
+type APIKeyAuthenticator struct {
+	keys []apiKey
+}
+
+// LoadAPIKeys reads API keys from a YAML file of the form
+//
+//	keys:
+//	  - name: ci
+//	    key: "<secret>"
+//	    tools: [get_action_parameters, validate_workflow]
+//	  - name: dashboard
+//	    sha256: "<hex-encoded SHA-256 hash of the secret>"
+//
+// Each key has a unique name identifying the principal, and either the key itself or its SHA-256 hash,
+// so that the file does not need to contain secrets. Keys are restricted to the listed tools, if any;
+// keys without tools may call all of them, while "tools: []" locks a key out of all tools.
+func LoadAPIKeys(path string) (*APIKeyAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API keys: %w", err)
+	}
+	var file apiKeysFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse API keys in %s: %w", path, err)
+	}
+
+	authenticator := &APIKeyAuthenticator{}
+	names := map[string]bool{}
+	for i, entry := range file.Keys {
+		if entry.Name == "" {
+			return nil, fmt.Errorf("invalid API key #%d in %s: name is required", i+1, path)
+		}
+		if names[entry.Name] {
+			return nil, fmt.Errorf("invalid API key '%s' in %s: duplicate name", entry.Name, path)
+		}
+		names[entry.Name] = true
+
+		key := apiKey{principal: Principal{Name: entry.Name, Tools: entry.Tools}}
+		switch {
+		case entry.Key != "" && entry.SHA256 != "":
+			return nil, fmt.Errorf("invalid API key '%s' in %s: expected either key or sha256", entry.Name, path)
+		case entry.Key != "":
+			key.hash = sha256.Sum256([]byte(entry.Key))
+		case entry.SHA256 != "":
+			hash, err := hex.DecodeString(entry.SHA256)
+			if err != nil || len(hash) != sha256.Size {
+				return nil, fmt.Errorf("invalid API key '%s' in %s: sha256 must be a hex-encoded SHA-256 hash", entry.Name, path)
+			}
+			copy(key.hash[:], hash)
+		default:
+			return nil, fmt.Errorf("invalid API key '%s' in %s: key or sha256 is required", entry.Name, path)
+		}
+		authenticator.keys = append(authenticator.keys, key)
+	}
+	return authenticator, nil
+}
+
+// Authenticate implements Authenticator. Keys are compared in constant time.
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	hash := sha256.Sum256([]byte(token))
+	var match *apiKey
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(hash[:], a.keys[i].hash[:]) == 1 {
+			match = &a.keys[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: unknown API key", auth.ErrInvalidToken)
+	}
+	principal := match.principal
+	return &principal, nil
+}
@@ -0,0 +1,314 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+func TestLoadAPIKeys(t *testing.T) {
+	hash := sha256.Sum256([]byte("hashed-secret"))
+
+	tests := []struct {
+		name     string
+		content  string
+		token    string
+		want     *Principal
+		wantErr  string
+		wantAuth bool
+	}{
+		{
+			name:    "plain key with tools",
+			content: "keys:\n  - name: ci\n    key: s3cr3t\n    tools: [get_readme]\n",
+			token:   "s3cr3t",
+			want:    &Principal{Name: "ci", Tools: []string{"get_readme"}},
+		},
+		{
+			name:    "hashed key",
+			content: "keys:\n  - name: dashboard\n    sha256: " + hex.EncodeToString(hash[:]) + "\n",
+			token:   "hashed-secret",
+			want:    &Principal{Name: "dashboard"},
+		},
+		{
+			name:    "key locked out of all tools",
+			content: "keys:\n  - name: locked\n    key: s3cr3t\n    tools: []\n",
+			token:   "s3cr3t",
+			want:    &Principal{Name: "locked", Tools: []string{}},
+		},
+		{
+			name:     "unknown key",
+			content:  "keys:\n  - name: ci\n    key: s3cr3t\n",
+			token:    "guess",
+			wantAuth: true,
+		},
+		{name: "missing name", content: "keys:\n  - key: s3cr3t\n", wantErr: "name is required"},
+		{name: "duplicate name", content: "keys:\n  - name: ci\n    key: a\n  - name: ci\n    key: b\n", wantErr: "duplicate name"},
+		{name: "missing key", content: "keys:\n  - name: ci\n", wantErr: "key or sha256 is required"},
+		{name: "key and hash", content: "keys:\n  - name: ci\n    key: a\n    sha256: " + hex.EncodeToString(hash[:]) + "\n", wantErr: "either key or sha256"},
+		{name: "invalid hash", content: "keys:\n  - name: ci\n    sha256: abc\n", wantErr: "hex-encoded SHA-256 hash"},
+		{name: "invalid YAML", content: "keys: [", wantErr: "failed to parse API keys"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "keys.yml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			authenticator, err := LoadAPIKeys(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadAPIKeys() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadAPIKeys() unexpected error: %v", err)
+			}
+
+			principal, err := authenticator.Authenticate(context.Background(), tt.token)
+			if tt.wantAuth {
+				if !errors.Is(err, auth.ErrInvalidToken) {
+					t.Errorf("Authenticate() error = %v, want auth.ErrInvalidToken", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() unexpected error: %v", err)
+			}
+			if principal.Name != tt.want.Name || !slices.Equal(principal.Tools, tt.want.Tools) || (principal.Tools == nil) != (tt.want.Tools == nil) {
+				t.Errorf("Authenticate() = %+v, want %+v", principal, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticators(t *testing.T) {
+	authenticators := Authenticators{
+		&APIKeyAuthenticator{keys: []apiKey{{hash: sha256.Sum256([]byte("first")), principal: Principal{Name: "first"}}}},
+		&APIKeyAuthenticator{keys: []apiKey{{hash: sha256.Sum256([]byte("second")), principal: Principal{Name: "second"}}}},
+	}
+
+	for _, token := range []string{"first", "second"} {
+		principal, err := authenticators.Authenticate(context.Background(), token)
+		if err != nil || principal.Name != token {
+			t.Errorf("Authenticate(%q) = %+v, %v, want principal %q", token, principal, err, token)
+		}
+	}
+	if _, err := authenticators.Authenticate(context.Background(), "third"); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("Authenticate(\"third\") error = %v, want auth.ErrInvalidToken", err)
+	}
+}
+
+func TestPrincipalMiddleware(t *testing.T) {
+	restricted := &Principal{Name: "ci", Tools: []string{"get_readme"}}
+
+	tests := []struct {
+		name             string
+		sessionPrincipal *Principal
+		tokenPrincipal   *Principal
+		tool             string
+		wantPrincipal    string
+		wantForbidden    bool
+	}{
+		{name: "no authentication", tool: "get_action_parameters"},
+		{name: "allowed tool", tokenPrincipal: restricted, tool: "get_readme", wantPrincipal: "ci"},
+		{name: "forbidden tool", tokenPrincipal: restricted, tool: "get_action_parameters", wantForbidden: true},
+		{name: "unrestricted principal", tokenPrincipal: &Principal{Name: "admin"}, tool: "get_action_parameters", wantPrincipal: "admin"},
+		{name: "session principal", sessionPrincipal: restricted, tool: "get_readme", wantPrincipal: "ci"},
+		{name: "empty tool list", tokenPrincipal: &Principal{Name: "locked", Tools: []string{}}, tool: "get_readme", wantForbidden: true},
+		{name: "token takes precedence", sessionPrincipal: restricted, tokenPrincipal: &Principal{Name: "admin"}, tool: "get_action_parameters", wantPrincipal: "admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			var gotPrincipal string
+			next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				called = true
+				if principal := PrincipalFromContext(ctx); principal != nil {
+					gotPrincipal = principal.Name
+				}
+				return &mcp.CallToolResult{}, nil
+			}
+			handler := NewMCPServer(github.NewActionsService(nil), nil).principalMiddleware(tt.sessionPrincipal)(next)
+
+			req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: tt.tool}}
+			if tt.tokenPrincipal != nil {
+				req.Extra = &mcp.RequestExtra{TokenInfo: &auth.TokenInfo{Extra: map[string]any{principalKeyName: tt.tokenPrincipal}}}
+			}
+			result, err := handler(context.Background(), "tools/call", req)
+			if err != nil {
+				t.Fatalf("handler() unexpected error: %v", err)
+			}
+
+			if tt.wantForbidden {
+				if called {
+					t.Error("handler() called the tool, want it to be forbidden")
+				}
+				if toolResult := result.(*mcp.CallToolResult); !toolResult.IsError || toolResult.Meta[ErrorCodeKey] != ErrorCodeForbidden {
+					t.Errorf("handler() = %+v, want an error result with code %q", toolResult, ErrorCodeForbidden)
+				}
+				return
+			}
+			if !called {
+				t.Fatal("handler() did not call the tool")
+			}
+			if gotPrincipal != tt.wantPrincipal {
+				t.Errorf("PrincipalFromContext() = %q, want %q", gotPrincipal, tt.wantPrincipal)
+			}
+		})
+	}
+}
+
+func TestPrincipalMiddleware_CompleteWithoutRef(t *testing.T) {
+	var called bool
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		called = true
+		return &mcp.CompleteResult{}, nil
+	}
+	handler := NewMCPServer(github.NewActionsService(nil), nil).principalMiddleware(nil)(next)
+
+	req := &mcp.CompleteRequest{
+		Params: &mcp.CompleteParams{Argument: mcp.CompleteParamsArgument{Name: "actionRef"}},
+		Extra:  &mcp.RequestExtra{TokenInfo: &auth.TokenInfo{Extra: map[string]any{principalKeyName: &Principal{Name: "ci", Tools: []string{"get_readme"}}}}},
+	}
+	if _, err := handler(context.Background(), "completion/complete", req); !errors.Is(err, errInvalidParams) {
+		t.Errorf("handler() error = %v, want an invalid params error", err)
+	}
+	if called {
+		t.Error("handler() called the completion handler, want the request to be rejected")
+	}
+}
+
+func TestHTTPHandler_Authentication(t *testing.T) {
+	authenticator := &APIKeyAuthenticator{keys: []apiKey{
+		{hash: sha256.Sum256([]byte("restricted-key")), principal: Principal{Name: "ci", Tools: []string{"get_readme", "list_action_versions"}}},
+	}}
+	handler := NewMCPServer(github.NewActionsService(nil), nil).NewHTTPHandler(&mcp.Implementation{Name: "test"}, &HTTPOptions{
+		Authenticator: authenticator,
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	t.Run("health check without token", func(t *testing.T) {
+		resp, err := http.Get(server.URL + PathHealthz)
+		if err != nil {
+			t.Fatalf("GET %s unexpected error: %v", PathHealthz, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET %s = %d, want 200", PathHealthz, resp.StatusCode)
+		}
+	})
+
+	for _, path := range []string{PathStreamableHTTP, PathSSE} {
+		for _, token := range []string{"", "wrong-key"} {
+			t.Run("rejects "+path+" with token '"+token+"'", func(t *testing.T) {
+				req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+				req.Header.Set("Accept", "text/event-stream")
+				if token != "" {
+					req.Header.Set("Authorization", "Bearer "+token)
+				}
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatalf("GET %s unexpected error: %v", path, err)
+				}
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusUnauthorized {
+					t.Errorf("GET %s = %d, want 401", path, resp.StatusCode)
+				}
+			})
+		}
+	}
+
+	transports := map[string]func(*http.Client) mcp.Transport{
+		"streamable HTTP": func(c *http.Client) mcp.Transport {
+			return &mcp.StreamableClientTransport{Endpoint: server.URL + PathStreamableHTTP, HTTPClient: c}
+		},
+		"SSE": func(c *http.Client) mcp.Transport {
+			return &mcp.SSEClientTransport{Endpoint: server.URL + PathSSE, HTTPClient: c}
+		},
+	}
+	for name, transport := range transports {
+		t.Run("restricts tools over "+name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &http.Client{Transport: bearerTransport{token: "restricted-key"}}
+			session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil).Connect(ctx, transport(client), nil)
+			if err != nil {
+				t.Fatalf("Connect() unexpected error: %v", err)
+			}
+			defer session.Close()
+
+			tools, err := session.ListTools(ctx, nil)
+			if err != nil {
+				t.Fatalf("ListTools() unexpected error: %v", err)
+			}
+			var names []string
+			for _, tool := range tools.Tools {
+				names = append(names, tool.Name)
+			}
+			slices.Sort(names)
+			if want := []string{"get_readme", "list_action_versions"}; !slices.Equal(names, want) {
+				t.Errorf("ListTools() = %v, want %v", names, want)
+			}
+
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{
+				Name:      "get_action_parameters",
+				Arguments: map[string]any{"actionRef": "actions/checkout@v4"},
+			})
+			if err != nil {
+				t.Fatalf("CallTool() unexpected error: %v", err)
+			}
+			if !result.IsError || result.Meta[ErrorCodeKey] != ErrorCodeForbidden {
+				t.Errorf("CallTool() = %+v, want an error result with code %q", result, ErrorCodeForbidden)
+			}
+
+			templates, err := session.ListResourceTemplates(ctx, nil)
+			if err != nil {
+				t.Fatalf("ListResourceTemplates() unexpected error: %v", err)
+			}
+			if len(templates.ResourceTemplates) != 1 || templates.ResourceTemplates[0].Name != "readme" {
+				t.Errorf("ListResourceTemplates() = %+v, want only the readme template", templates.ResourceTemplates)
+			}
+			if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "gha://action/actions/checkout@v5"}); err == nil || !strings.Contains(err.Error(), "forbidden") {
+				t.Errorf("ReadResource() error = %v, want it to be forbidden", err)
+			}
+
+			prompts, err := session.ListPrompts(ctx, nil)
+			if err != nil {
+				t.Fatalf("ListPrompts() unexpected error: %v", err)
+			}
+			if len(prompts.Prompts) != 0 {
+				t.Errorf("ListPrompts() = %+v, want no prompts", prompts.Prompts)
+			}
+			if _, err := session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "write_step_for_action", Arguments: map[string]string{"actionRef": "actions/checkout@v5"}}); err == nil || !strings.Contains(err.Error(), "forbidden") {
+				t.Errorf("GetPrompt() error = %v, want it to be forbidden", err)
+			}
+		})
+	}
+}
+
+// bearerTransport adds a bearer token to every request.
+type bearerTransport struct {
+	token string
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,189 @@
+package mcp
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// maxCompletionValues is the number of values returned for a completion, the most MCP allows.
+const maxCompletionValues = 100
+
+// DefaultCompletionOwners are the owners offered when completing the first segment of an action or
+// repository reference, unless others are configured (see SetCompletionOwners).
+var DefaultCompletionOwners = []string{
+	"actions",
+	"github",
+	"docker",
+	"aws-actions",
+	"azure",
+	"google-github-actions",
+	"hashicorp",
+	"golangci",
+	"goreleaser",
+	"softprops",
+	"peaceiris",
+	"peter-evans",
+	"codecov",
+	"dtolnay",
+	"astral-sh",
+	"pnpm",
+	"oven-sh",
+	"ruby",
+	"gradle",
+	"sigstore",
+}
+
+// SetCompletionOwners sets the owners, e.g. the user's organizations, offered when completing the
+// first segment of an action or repository reference. If nil, DefaultCompletionOwners are offered.
+func (m *MCPServer) SetCompletionOwners(owners []string) {
+	m.completionOwners = owners
+}
+
+// handleComplete completes the arguments of prompts and resource templates referencing actions and
+// repositories: owners from the configured list, repositories via GitHub's repository search, and
+// versions from the repository's tags. Typing "actions/setup-" offers "actions/setup-node",
+// "actions/setup-python" and so on; "actions/setup-node@" then offers its versions.
+//
+// Completion is best-effort: if GitHub cannot be reached, no values are offered.
+func (m *MCPServer) handleComplete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	params := req.Params
+	if params.Ref == nil {
+		return nil, invalidParams("ref is required")
+	}
+	if params.Argument.Name == "" {
+		return nil, invalidParams("argument name is required")
+	}
+	value := params.Argument.Value
+	var arguments map[string]string
+	if params.Context != nil {
+		arguments = params.Context.Arguments
+	}
+
+	var values []string
+	switch params.Ref.Type {
+	case "ref/prompt":
+		switch params.Argument.Name {
+		case "actionRef", "repoRef":
+			values = m.completeRef(ctx, value)
+		case "toVersion":
+			if ref, err := github.ParseActionRef(arguments["actionRef"]); err == nil {
+				values = m.completeVersions(ctx, ref.Owner, ref.Repo, value)
+			}
+		}
+	case "ref/resource":
+		if resourceTool(params.Ref.URI) == "" {
+			break
+		}
+		switch params.Argument.Name {
+		case "owner":
+			values = m.completeOwners(value)
+		case "repo":
+			values = m.completeRepos(ctx, arguments["owner"], value)
+		case "ref":
+			values = m.completeVersions(ctx, arguments["owner"], arguments["repo"], value)
+		}
+	}
+	return completionResult(values), nil
+}
+
+// completeRef completes a reference such as "actions/setup-node@v4", depending on the segment
+// being typed: the owner, the repository or the version after "@".
+func (m *MCPServer) completeRef(ctx context.Context, value string) []string {
+	if repository, version, ok := strings.Cut(value, "@"); ok {
+		owner, rest, _ := strings.Cut(repository, "/")
+		repo, _, _ := strings.Cut(rest, "/")
+		return prefixValues(repository+"@", m.completeVersions(ctx, owner, repo, version))
+	}
+
+	owner, repo, ok := strings.Cut(value, "/")
+	if !ok {
+		return prefixValues("", m.completeOwners(owner))
+	}
+	// Actions in subdirectories ("github/codeql-action/init") cannot be completed
+	if strings.Contains(repo, "/") {
+		return nil
+	}
+	return prefixValues(owner+"/", m.completeRepos(ctx, owner, repo))
+}
+
+// completeOwners returns the configured owners starting with prefix, ignoring case, each followed
+// by "/" so that the repository can be typed next.
+func (m *MCPServer) completeOwners(prefix string) []string {
+	owners := m.completionOwners
+	if owners == nil {
+		owners = DefaultCompletionOwners
+	}
+	var values []string
+	for _, owner := range owners {
+		if strings.HasPrefix(strings.ToLower(owner), strings.ToLower(prefix)) {
+			values = append(values, owner+"/")
+		}
+	}
+	return values
+}
+
+// completeRepos returns the repositories of owner whose name starts with prefix, most popular first.
+func (m *MCPServer) completeRepos(ctx context.Context, owner, prefix string) []string {
+	if owner == "" {
+		return nil
+	}
+	repos, err := m.actionsService.SearchRepositories(ctx, owner, prefix)
+	if err != nil {
+		m.logger.Debug("failed to complete repositories", "owner", owner, "error", err)
+		return nil
+	}
+	return repos
+}
+
+// completeVersions returns the versions of a repository starting with prefix: "latest", the moving
+// tags such as "v4" and the releases, newest first.
+func (m *MCPServer) completeVersions(ctx context.Context, owner, repo, prefix string) []string {
+	if owner == "" || repo == "" {
+		return nil
+	}
+	versions, err := m.actionsService.ListActionVersions(ctx, owner+"/"+repo)
+	if err != nil {
+		m.logger.Debug("failed to complete versions", "repository", owner+"/"+repo, "error", err)
+		return nil
+	}
+
+	candidates := []string{}
+	if versions.Latest != nil {
+		candidates = append(candidates, github.VersionLatest)
+	}
+	for _, version := range slices.Concat(versions.MovingTags, versions.Releases) {
+		candidates = append(candidates, version.Tag)
+	}
+	var values []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			values = append(values, candidate)
+		}
+	}
+	return values
+}
+
+// prefixValues prepends prefix to each value.
+func prefixValues(prefix string, values []string) []string {
+	for i, value := range values {
+		values[i] = prefix + value
+	}
+	return values
+}
+
+// completionResult returns the first maxCompletionValues values, reporting whether there are more.
+func completionResult(values []string) *mcp.CompleteResult {
+	result := &mcp.CompleteResult{
+		Completion: mcp.CompletionResultDetails{Values: []string{}, Total: len(values)},
+	}
+	if len(values) > maxCompletionValues {
+		values = values[:maxCompletionValues]
+		result.Completion.HasMore = true
+	}
+	result.Completion.Values = append(result.Completion.Values, values...)
+	return result
+}
@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// newCompletionTestService creates an ActionsService serving the repository search of actions and
+// the tags of actions/setup-node.
+func newCompletionTestService(t *testing.T) *github.ActionsService {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/search/repositories":
+			if r.URL.Query().Get("q") != "setup- in:name user:actions fork:true" {
+				fmt.Fprint(w, `{"items": []}`)
+				return
+			}
+			fmt.Fprint(w, `{"items": [{"name": "setup-node"}, {"name": "setup-python"}]}`)
+		case "/api/v3/repos/actions/setup-node/tags":
+			fmt.Fprint(w, `[
+				{"name": "v4.1.0", "commit": {"sha": "sha-410"}},
+				{"name": "v4", "commit": {"sha": "sha-410"}},
+				{"name": "v3.9.0", "commit": {"sha": "sha-390"}},
+				{"name": "v3", "commit": {"sha": "sha-390"}}
+			]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return github.NewActionsService(&github.ActionsServiceOptions{
+		Endpoints: &github.Endpoints{
+			WebURL: server.URL,
+			APIURL: server.URL + "/api/v3",
+			RawURL: server.URL + "/raw",
+		},
+	})
+}
+
+func TestCompletion(t *testing.T) {
+	session := connectTestClient(t, newCompletionTestService(t))
+
+	promptRef := &mcp.CompleteReference{Type: "ref/prompt", Name: "write_step_for_action"}
+	actionResourceRef := &mcp.CompleteReference{Type: "ref/resource", URI: "gha://action/{owner}/{repo}{/path*}@{ref}"}
+
+	tests := []struct {
+		name      string
+		ref       *mcp.CompleteReference
+		argument  string
+		value     string
+		arguments map[string]string
+		want      []string
+	}{
+		{name: "owner", ref: promptRef, argument: "actionRef", value: "act", want: []string{"actions/"}},
+		{name: "owner ignoring case", ref: promptRef, argument: "actionRef", value: "AWS", want: []string{"aws-actions/"}},
+		{name: "repository", ref: promptRef, argument: "actionRef", value: "actions/setup-", want: []string{"actions/setup-node", "actions/setup-python"}},
+		{name: "version", ref: promptRef, argument: "actionRef", value: "actions/setup-node@", want: []string{"actions/setup-node@latest", "actions/setup-node@v4", "actions/setup-node@v3", "actions/setup-node@v4.1.0", "actions/setup-node@v3.9.0"}},
+		{name: "version prefix", ref: promptRef, argument: "actionRef", value: "actions/setup-node@v4", want: []string{"actions/setup-node@v4", "actions/setup-node@v4.1.0"}},
+		{name: "unknown repository", ref: promptRef, argument: "actionRef", value: "actions/missing@", want: []string{}},
+		{name: "action in subdirectory", ref: promptRef, argument: "actionRef", value: "github/codeql-action/in", want: []string{}},
+		{name: "other argument", ref: promptRef, argument: "goal", value: "act", want: []string{}},
+		{
+			name:      "target version",
+			ref:       &mcp.CompleteReference{Type: "ref/prompt", Name: "upgrade_action"},
+			argument:  "toVersion",
+			value:     "v3",
+			arguments: map[string]string{"actionRef": "actions/setup-node@v2"},
+			want:      []string{"v3", "v3.9.0"},
+		},
+		{name: "resource owner", ref: actionResourceRef, argument: "owner", value: "dock", want: []string{"docker/"}},
+		{name: "resource repository", ref: actionResourceRef, argument: "repo", value: "setup-", arguments: map[string]string{"owner": "actions"}, want: []string{"setup-node", "setup-python"}},
+		{name: "resource ref", ref: actionResourceRef, argument: "ref", value: "v4", arguments: map[string]string{"owner": "actions", "repo": "setup-node"}, want: []string{"v4", "v4.1.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &mcp.CompleteParams{
+				Ref:      tt.ref,
+				Argument: mcp.CompleteParamsArgument{Name: tt.argument, Value: tt.value},
+			}
+			if tt.arguments != nil {
+				params.Context = &mcp.CompleteContext{Arguments: tt.arguments}
+			}
+			result, err := session.Complete(context.Background(), params)
+			if err != nil {
+				t.Fatalf("Complete() unexpected error: %v", err)
+			}
+			if !slices.Equal(result.Completion.Values, tt.want) {
+				t.Errorf("Complete() = %v, want %v", result.Completion.Values, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletion_InvalidParams(t *testing.T) {
+	session := connectTestClient(t, github.NewActionsService(nil))
+
+	tests := []struct {
+		name   string
+		params *mcp.CompleteParams
+	}{
+		{name: "no ref", params: &mcp.CompleteParams{Argument: mcp.CompleteParamsArgument{Name: "actionRef", Value: "act"}}},
+		{name: "no argument name", params: &mcp.CompleteParams{Ref: &mcp.CompleteReference{Type: "ref/prompt", Name: "write_step_for_action"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := session.Complete(context.Background(), tt.params); !errors.Is(err, errInvalidParams) {
+				t.Errorf("Complete() error = %v, want an invalid params error", err)
+			}
+		})
+	}
+
+	// The server is still serving after the invalid requests
+	if _, err := session.Complete(context.Background(), &mcp.CompleteParams{
+		Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "write_step_for_action"},
+		Argument: mcp.CompleteParamsArgument{Name: "actionRef", Value: "act"},
+	}); err != nil {
+		t.Errorf("Complete() unexpected error: %v", err)
+	}
+}
+
+func TestCompletion_ConfiguredOwners(t *testing.T) {
+	m := NewMCPServer(github.NewActionsService(nil), nil)
+	m.SetCompletionOwners([]string{"my-org", "my-other-org", "actions"})
+
+	if got, want := m.completeRef(context.Background(), "my"), []string{"my-org/", "my-other-org/"}; !slices.Equal(got, want) {
+		t.Errorf("completeRef() = %v, want %v", got, want)
+	}
+}
+
+func TestCompletionResult(t *testing.T) {
+	values := make([]string, maxCompletionValues+5)
+	result := completionResult(values)
+	if len(result.Completion.Values) != maxCompletionValues || !result.Completion.HasMore || result.Completion.Total != len(values) {
+		t.Errorf("completionResult() = %d values, hasMore %t, total %d, want %d values, more and total %d",
+			len(result.Completion.Values), result.Completion.HasMore, result.Completion.Total, maxCompletionValues, len(values))
+	}
+}
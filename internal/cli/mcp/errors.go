@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// ErrorCodeKey is the key of the machine-readable error code in the _meta field of error results.
+const ErrorCodeKey = "errorCode"
+
+// Error codes reported in the _meta field of tool results with IsError set.
+// They allow agents to decide how to react to a failure without parsing the error message.
+const (
+	// ErrorCodeInvalidArgument indicates a missing or malformed tool argument. Correct the arguments.
+	ErrorCodeInvalidArgument = "invalid_argument"
+	// ErrorCodeInvalidRef indicates a malformed action or repository reference. Correct the reference.
+	ErrorCodeInvalidRef = "invalid_ref"
+	// ErrorCodeNotFound indicates that the file, ref or repository does not exist or is not accessible.
+	ErrorCodeNotFound = "not_found"
+	// ErrorCodeUnauthorized indicates a missing or insufficient GitHub token. Provide a token.
+	ErrorCodeUnauthorized = "unauthorized"
+	// ErrorCodeRateLimited indicates an exceeded GitHub rate limit. Retry later or provide a token.
+	ErrorCodeRateLimited = "rate_limited"
+	// ErrorCodeNoWorkspace indicates a local reference ("./...") while no workspace is known.
+	// Start the server with --workspace or provide MCP roots, or reference the action on GitHub.
+	ErrorCodeNoWorkspace = "no_workspace"
+	// ErrorCodeForbidden indicates a tool the authenticated API key or token is not allowed to call.
+	ErrorCodeForbidden = "forbidden"
+	// ErrorCodeTimeout indicates that the operation did not finish in time. Retrying may succeed.
+	ErrorCodeTimeout = "timeout"
+	// ErrorCodeInternal indicates any other failure, e.g. a network error or an invalid action.yml.
+	ErrorCodeInternal = "internal"
+)
+
+// errInvalidArgument marks errors caused by invalid tool arguments.
+var errInvalidArgument = errors.New("invalid argument")
+
+// errForbidden marks tool calls the authenticated principal is not allowed to make.
+var errForbidden = errors.New("forbidden")
+
+// errInvalidParams is the JSON-RPC "invalid params" error (code -32602). Errors wrapping it are
+// sent to the client with its code. The SDK does not export its JSON-RPC error type, so the error
+// is taken from a decoded error response.
+var errInvalidParams = func() error {
+	msg, err := jsonrpc.DecodeMessage([]byte(`{"jsonrpc":"2.0","id":0,"error":{"code":-32602,"message":"invalid params"}}`))
+	if err != nil {
+		panic(fmt.Sprintf("errInvalidParams: %v", err))
+	}
+	return msg.(*jsonrpc.Response).Error
+}()
+
+// invalidParams creates an error for a malformed request, sent to the client as errInvalidParams.
+func invalidParams(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errInvalidParams, fmt.Sprintf(format, args...))
+}
+
+// invalidArgument creates an error for an invalid tool argument, reported with ErrorCodeInvalidArgument.
+func invalidArgument(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errInvalidArgument, fmt.Sprintf(format, args...))
+}
+
+// errorCode maps an error to the error code reported to MCP clients.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, errInvalidArgument):
+		return ErrorCodeInvalidArgument
+	case errors.Is(err, github.ErrInvalidRef):
+		return ErrorCodeInvalidRef
+	case errors.Is(err, github.ErrNotFound):
+		return ErrorCodeNotFound
+	case errors.Is(err, github.ErrUnauthorized):
+		return ErrorCodeUnauthorized
+	case errors.Is(err, github.ErrRateLimited):
+		return ErrorCodeRateLimited
+	case errors.Is(err, github.ErrNoWorkspace):
+		return ErrorCodeNoWorkspace
+	case errors.Is(err, errForbidden):
+		return ErrorCodeForbidden
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeTimeout
+	default:
+		return ErrorCodeInternal
+	}
+}
+
+// toolError creates a tool result reporting err to the client.
+// The result has IsError set, the error message as text content and the
+// error code (see errorCode) in its _meta field under ErrorCodeKey.
+// Handlers return it with a nil error, so that the error code reaches the client.
+func toolError(err error) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Meta: mcp.Meta{ErrorCodeKey: errorCode(err)},
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: err.Error()},
+		},
+		IsError: true,
+	}
+}
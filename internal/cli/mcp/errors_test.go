@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "invalid argument", err: invalidArgument("actionRef is required"), want: ErrorCodeInvalidArgument},
+		{name: "invalid ref", err: wrap(github.ParseActionRef("actions/checkout")), want: ErrorCodeInvalidRef},
+		{name: "not found", err: fmt.Errorf("failed: %w", &github.HTTPError{StatusCode: 404}), want: ErrorCodeNotFound},
+		{name: "unauthorized", err: fmt.Errorf("failed: %w", &github.HTTPError{StatusCode: 401}), want: ErrorCodeUnauthorized},
+		{name: "rate limited", err: fmt.Errorf("failed: %w", &github.HTTPError{StatusCode: 429}), want: ErrorCodeRateLimited},
+		{name: "no workspace", err: wrap(github.NewActionsService(nil).ReadLocalFile(context.Background(), "action.yml")), want: ErrorCodeNoWorkspace},
+		{name: "timeout", err: fmt.Errorf("failed: %w", context.DeadlineExceeded), want: ErrorCodeTimeout},
+		{name: "server error", err: fmt.Errorf("failed: %w", &github.HTTPError{StatusCode: 502}), want: ErrorCodeInternal},
+		{name: "other", err: errors.New("failed to parse YAML"), want: ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCode(tt.err); got != tt.want {
+				t.Errorf("errorCode(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// wrap wraps the error of a call returning a value and an error, as the tool handlers do.
+func wrap[T any](_ T, err error) error {
+	return fmt.Errorf("invalid action reference: %w", err)
+}
+
+func TestToolError(t *testing.T) {
+	result := toolError(fmt.Errorf("failed to get action parameters: %w", github.ErrNotFound))
+
+	if !result.IsError {
+		t.Errorf("toolError() IsError = false, want true")
+	}
+	if got := result.Meta[ErrorCodeKey]; got != ErrorCodeNotFound {
+		t.Errorf("toolError() error code = %v, want %q", got, ErrorCodeNotFound)
+	}
+	if len(result.Content) != 1 {
+		t.Fatalf("toolError() returned %d content items, want 1", len(result.Content))
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("toolError() content is %T, want *mcp.TextContent", result.Content[0])
+	}
+	if want := "failed to get action parameters: not found"; text.Text != want {
+		t.Errorf("toolError() text = %q, want %q", text.Text, want)
+	}
+}
+
+func TestHandleGetActionParameters_InvalidArgument(t *testing.T) {
+	server := NewMCPServer(github.NewActionsService(nil), nil)
+
+	result, data, err := server.handleGetActionParameters(context.Background(), &mcp.CallToolRequest{}, GetActionParametersArgs{})
+	if err != nil {
+		t.Fatalf("handleGetActionParameters() unexpected error: %v", err)
+	}
+	if data != nil {
+		t.Errorf("handleGetActionParameters() data = %v, want nil for error results", data)
+	}
+	if !result.IsError || result.Meta[ErrorCodeKey] != ErrorCodeInvalidArgument {
+		t.Errorf("handleGetActionParameters() = %+v, want an error result with code %q", result, ErrorCodeInvalidArgument)
+	}
+}
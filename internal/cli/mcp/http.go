@@ -0,0 +1,125 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// httpKeepAlive is the interval in which clients connected over HTTP are pinged.
+	// Sessions of clients which do not respond are closed.
+	httpKeepAlive = 30 * time.Second
+
+	// shutdownTimeout is how long in-flight requests may take to complete on shutdown,
+	// before the connections of remaining clients are closed.
+	shutdownTimeout = 10 * time.Second
+)
+
+// Paths served by the HTTP handler (see NewHTTPHandler).
+const (
+	PathStreamableHTTP = "/mcp"
+	PathSSE            = "/sse"
+	PathHealthz        = "/healthz"
+)
+
+// HTTPOptions configures the HTTP transport (see NewHTTPHandler).
+type HTTPOptions struct {
+	// Authenticator verifies the bearer tokens of requests to the MCP endpoints.
+	// Requests are not authenticated if nil.
+	Authenticator Authenticator
+}
+
+// NewHTTPHandler returns an http.Handler serving the MCP server over the streamable HTTP
+// transport at /mcp, and over the legacy HTTP+SSE transport at /sse for older clients.
+// GET /healthz reports whether the server is up, e.g. for container health checks.
+//
+// If an Authenticator is configured, requests to the MCP endpoints need a valid bearer token,
+// and tool handlers see the principal it belongs to in their context (see PrincipalFromContext).
+// The health check is not authenticated.
+func (m *MCPServer) NewHTTPHandler(impl *mcp.Implementation, opts *HTTPOptions) http.Handler {
+	if opts == nil {
+		opts = &HTTPOptions{}
+	}
+	streamableServer := m.NewServer(impl, TransportHTTP)
+	sseServer := m.NewServer(impl, TransportSSE)
+
+	var streamableHandler http.Handler = mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return streamableServer
+	}, nil)
+	var sseHandler http.Handler = mcp.NewSSEHandler(func(r *http.Request) *mcp.Server {
+		// SSE requests do not carry their token to the server, so each session is bound to its principal
+		if principal := principalFromTokenInfo(auth.TokenInfoFromContext(r.Context())); principal != nil {
+			return m.newServer(impl, TransportSSE, principal)
+		}
+		return sseServer
+	}, nil)
+	if opts.Authenticator != nil {
+		streamableHandler = requireAuth(opts.Authenticator, streamableHandler)
+		sseHandler = requireAuth(opts.Authenticator, sseHandler)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(PathStreamableHTTP, streamableHandler)
+	mux.Handle(PathSSE, sseHandler)
+	mux.HandleFunc("GET "+PathHealthz, handleHealthz)
+	return mux
+}
+
+// handleHealthz reports that the server is up.
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "ok")
+}
+
+// ListenAndServe serves the MCP server over HTTP on the given address (see NewHTTPHandler)
+// until the context is cancelled, e.g. on SIGTERM. It then shuts down gracefully, giving
+// in-flight requests time to complete.
+func (m *MCPServer) ListenAndServe(ctx context.Context, addr string, impl *mcp.Implementation, opts *HTTPOptions) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return m.serveHTTP(ctx, listener, impl, opts)
+}
+
+// serveHTTP serves the MCP server over HTTP on the listener until the context is cancelled.
+func (m *MCPServer) serveHTTP(ctx context.Context, listener net.Listener, impl *mcp.Implementation, opts *HTTPOptions) error {
+	server := &http.Server{
+		Handler:           m.NewHTTPHandler(impl, opts),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Serve(listener)
+	}()
+	m.logger.Info("serving MCP over HTTP", "addr", listener.Addr().String(), "streamable", PathStreamableHTTP, "sse", PathSSE)
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("failed to serve HTTP: %w", err)
+	case <-ctx.Done():
+	}
+
+	m.logger.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		// Clients with open event streams keep their connections busy
+		m.logger.Warn("closing remaining connections", "error", err)
+		if err := server.Close(); err != nil {
+			return fmt.Errorf("failed to close HTTP server: %w", err)
+		}
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve HTTP: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+func TestHTTPHandler_Healthz(t *testing.T) {
+	server := httptest.NewServer(NewMCPServer(github.NewActionsService(nil), nil).NewHTTPHandler(&mcp.Implementation{Name: "test"}, nil))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + PathHealthz)
+	if err != nil {
+		t.Fatalf("GET %s unexpected error: %v", PathHealthz, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != "ok" {
+		t.Errorf("GET %s = %d %q, want 200 \"ok\"", PathHealthz, resp.StatusCode, body)
+	}
+}
+
+func TestHTTPHandler_Transports(t *testing.T) {
+	dir := writeTestAction(t, "Over HTTP")
+	service := github.NewActionsService(&github.ActionsServiceOptions{Workspace: dir})
+	server := httptest.NewServer(NewMCPServer(service, nil).NewHTTPHandler(&mcp.Implementation{Name: "test"}, nil))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		transport mcp.Transport
+	}{
+		{name: "streamable HTTP", transport: &mcp.StreamableClientTransport{Endpoint: server.URL + PathStreamableHTTP}},
+		{name: "SSE", transport: &mcp.SSEClientTransport{Endpoint: server.URL + PathSSE}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+			session, err := client.Connect(ctx, tt.transport, nil)
+			if err != nil {
+				t.Fatalf("Connect() unexpected error: %v", err)
+			}
+			defer session.Close()
+
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{
+				Name:      "get_action_parameters",
+				Arguments: map[string]any{"actionRef": "./"},
+			})
+			if err != nil {
+				t.Fatalf("CallTool() unexpected error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("CallTool() returned an error result: %+v", result.Content[0])
+			}
+			if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "Name: Over HTTP") {
+				t.Errorf("CallTool() text = %q, want it to contain the action name", text)
+			}
+		})
+	}
+}
+
+func TestHTTPHandler_IgnoresRoots(t *testing.T) {
+	rootDir := writeTestAction(t, "From roots")
+	root := &mcp.Root{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(rootDir)}).String()}
+	server := httptest.NewServer(NewMCPServer(github.NewActionsService(nil), nil).NewHTTPHandler(&mcp.Implementation{Name: "test"}, nil))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		transport mcp.Transport
+	}{
+		{name: "streamable HTTP", transport: &mcp.StreamableClientTransport{Endpoint: server.URL + PathStreamableHTTP}},
+		{name: "SSE", transport: &mcp.SSEClientTransport{Endpoint: server.URL + PathSSE}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+			client.AddRoots(root)
+			session, err := client.Connect(ctx, tt.transport, nil)
+			if err != nil {
+				t.Fatalf("Connect() unexpected error: %v", err)
+			}
+			defer session.Close()
+
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{
+				Name:      "get_action_parameters",
+				Arguments: map[string]any{"actionRef": "./"},
+			})
+			if err != nil {
+				t.Fatalf("CallTool() unexpected error: %v", err)
+			}
+			if !result.IsError || result.Meta[ErrorCodeKey] != ErrorCodeNoWorkspace {
+				t.Errorf("CallTool() = %+v, want an error result with code %q", result, ErrorCodeNoWorkspace)
+			}
+		})
+	}
+}
+
+func TestServeHTTP_Shutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- NewMCPServer(github.NewActionsService(nil), nil).serveHTTP(ctx, listener, &mcp.Implementation{Name: "test"}, nil)
+	}()
+
+	resp, err := http.Get("http://" + listener.Addr().String() + PathHealthz)
+	if err != nil {
+		t.Fatalf("GET %s unexpected error: %v", PathHealthz, err)
+	}
+	resp.Body.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("serveHTTP() unexpected error: %v", err)
+		}
+	case <-time.After(shutdownTimeout):
+		t.Fatal("serveHTTP() did not return after the context was cancelled")
+	}
+}
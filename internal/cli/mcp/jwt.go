@@ -0,0 +1,336 @@
+package mcp
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha512" // register crypto.SHA384 and crypto.SHA512
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// jwtLeeway is the clock skew tolerated when checking the exp and nbf claims of a JWT.
+const jwtLeeway = time.Minute
+
+// minRSAKeyBits is the minimum size of RSA keys verifying JWTs, as required by RFC 7518.
+const minRSAKeyBits = 2048
+
+// DefaultToolScopePrefix is the prefix of the OAuth2 scopes granting access to tools, e.g.
+// "mcp:tool:get_readme" for get_readme, or "mcp:tool:*" for all tools.
+const DefaultToolScopePrefix = "mcp:tool:"
+
+// JWTOptions are the claims a JWT must have to be accepted by a JWTAuthenticator.
+type JWTOptions struct {
+	// Issuer is the required iss claim. Any issuer is accepted if empty.
+	Issuer string
+	// Audience must be one of the aud claim's values. Any audience is accepted if empty.
+	Audience string
+	// ToolScopePrefix is the prefix of the scopes granting access to tools. If empty,
+	// DefaultToolScopePrefix is used.
+	ToolScopePrefix string
+}
+
+// JWTAuthenticator authenticates OAuth2 bearer tokens which are JWTs signed by one of the keys of a JWKS.
+// Tokens signed with RS256, RS384, RS512, PS256, PS384, PS512, ES256, ES384 or ES512 are supported;
+// they must not be expired and must have an exp claim. The principal is named after the sub claim
+// and may only call the tools granted by the token's scopes (see JWTOptions.ToolScopePrefix).
+type JWTAuthenticator struct {
+	keys    []jwk
+	options JWTOptions
+	now     func() time.Time
+}
+
+// jwk is a public key of a JSON Web Key Set (RFC 7517).
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC keys
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+
+	publicKey crypto.PublicKey
+}
+
+// LoadJWKS reads the keys verifying JWTs from a JSON Web Key Set file, e.g. a copy of the
+// identity provider's jwks_uri. Keys which are not signing keys or of an unsupported type are ignored.
+func LoadJWKS(path string, options JWTOptions) (*JWTAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS in %s: %w", path, err)
+	}
+
+	if options.ToolScopePrefix == "" {
+		options.ToolScopePrefix = DefaultToolScopePrefix
+	}
+	authenticator := &JWTAuthenticator{options: options, now: time.Now}
+	for i, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		switch key.Kty {
+		case "RSA":
+			key.publicKey, err = key.rsaPublicKey()
+		case "EC":
+			key.publicKey, err = key.ecdsaPublicKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid key #%d (%s) in %s: %w", i+1, key.Kid, path, err)
+		}
+		authenticator.keys = append(authenticator.keys, key)
+	}
+	if len(authenticator.keys) == 0 {
+		return nil, fmt.Errorf("no RSA or EC signing keys in %s", path)
+	}
+	return authenticator, nil
+}
+
+// rsaPublicKey decodes the modulus and exponent of an RSA key.
+func (k *jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil || len(n) == 0 {
+		return nil, errors.New("invalid modulus")
+	}
+	modulus := new(big.Int).SetBytes(n)
+	if modulus.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("RSA key has %d bits, at least %d are required", modulus.BitLen(), minRSAKeyBits)
+	}
+	// The exponent must be odd and fit into an int on all platforms, as required by crypto/rsa
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid exponent")
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if exponent.Cmp(big.NewInt(3)) < 0 || exponent.Cmp(big.NewInt(1<<31-1)) > 0 || exponent.Bit(0) == 0 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}, nil
+}
+
+// ecdsaPublicKey decodes the curve and point of an EC key.
+func (k *jwk) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve '%s'", k.Crv)
+	}
+	x, errX := base64.RawURLEncoding.DecodeString(k.X)
+	y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+	if errX != nil || errY != nil {
+		return nil, errors.New("invalid point")
+	}
+	// Uncompressed point encoding: 0x04 || X || Y, with coordinates padded to the curve size
+	size := (curve.Params().BitSize + 7) / 8
+	if len(x) > size || len(y) > size {
+		return nil, errors.New("invalid point")
+	}
+	point := make([]byte, 1+2*size)
+	point[0] = 4
+	copy(point[1+size-len(x):], x)
+	copy(point[1+2*size-len(y):], y)
+	return ecdsa.ParseUncompressedPublicKey(curve, point)
+}
+
+// jwtAlgorithm describes how a JWS algorithm verifies signatures.
+type jwtAlgorithm struct {
+	kty  string
+	hash crypto.Hash
+	pss  bool
+	// crv is the curve ECDSA algorithms are bound to.
+	crv string
+}
+
+// jwtAlgorithms are the supported JWS algorithms (RFC 7518). Symmetric algorithms and "none" are rejected.
+var jwtAlgorithms = map[string]jwtAlgorithm{
+	"RS256": {kty: "RSA", hash: crypto.SHA256},
+	"RS384": {kty: "RSA", hash: crypto.SHA384},
+	"RS512": {kty: "RSA", hash: crypto.SHA512},
+	"PS256": {kty: "RSA", hash: crypto.SHA256, pss: true},
+	"PS384": {kty: "RSA", hash: crypto.SHA384, pss: true},
+	"PS512": {kty: "RSA", hash: crypto.SHA512, pss: true},
+	"ES256": {kty: "EC", hash: crypto.SHA256, crv: "P-256"},
+	"ES384": {kty: "EC", hash: crypto.SHA384, crv: "P-384"},
+	"ES512": {kty: "EC", hash: crypto.SHA512, crv: "P-521"},
+}
+
+// jwtClaims are the registered claims checked by JWTAuthenticator.
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+	// Scope is the space-separated list of OAuth2 scopes (RFC 8693).
+	Scope string `json:"scope"`
+}
+
+// audience is the aud claim, which is either a single string or an array of strings.
+type audience []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return errors.New("aud must be a string or an array of strings")
+	}
+	*a = multiple
+	return nil
+}
+
+// Authenticate implements Authenticator. It verifies the signature of the JWT and its
+// exp, nbf, iss and aud claims.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	claims, err := a.verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", auth.ErrInvalidToken, err)
+	}
+	scopes := strings.Fields(claims.Scope)
+	return &Principal{
+		Name:       claims.Subject,
+		Tools:      a.toolsFromScopes(scopes),
+		Scopes:     scopes,
+		Expiration: time.Unix(*claims.ExpiresAt, 0),
+	}, nil
+}
+
+// toolsFromScopes returns the tools granted by scopes such as "mcp:tool:get_readme" (see
+// Principal.Tools): nil if the wildcard scope "mcp:tool:*" grants all tools, and an empty list
+// if no tool is granted.
+func (a *JWTAuthenticator) toolsFromScopes(scopes []string) []string {
+	tools := []string{}
+	for _, scope := range scopes {
+		tool, ok := strings.CutPrefix(scope, a.options.ToolScopePrefix)
+		switch {
+		case !ok || tool == "":
+		case tool == "*":
+			return nil
+		default:
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// verify checks the signature and claims of a JWT and returns its claims.
+func (a *JWTAuthenticator) verify(token string) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed JWT")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+	algorithm, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm '%s'", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed JWT signature")
+	}
+
+	hasher := algorithm.hash.New()
+	hasher.Write([]byte(parts[0] + "." + parts[1]))
+	digest := hasher.Sum(nil)
+	if !slices.ContainsFunc(a.keys, func(key jwk) bool {
+		return (header.Kid == "" || key.Kid == header.Kid) &&
+			(key.Alg == "" || key.Alg == header.Alg) &&
+			key.Kty == algorithm.kty &&
+			key.Crv == algorithm.crv &&
+			verifySignature(key.publicKey, algorithm, digest, signature)
+	}) {
+		return nil, errors.New("invalid signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed JWT claims: %w", err)
+	}
+	now := a.now()
+	switch {
+	case claims.ExpiresAt == nil:
+		return nil, errors.New("token has no expiration")
+	case now.After(time.Unix(*claims.ExpiresAt, 0).Add(jwtLeeway)):
+		return nil, errors.New("token expired")
+	case claims.NotBefore != nil && now.Add(jwtLeeway).Before(time.Unix(*claims.NotBefore, 0)):
+		return nil, errors.New("token not valid yet")
+	case a.options.Issuer != "" && claims.Issuer != a.options.Issuer:
+		return nil, fmt.Errorf("unexpected issuer '%s'", claims.Issuer)
+	case a.options.Audience != "" && !slices.Contains(claims.Audience, a.options.Audience):
+		return nil, errors.New("token is not issued for this audience")
+	case claims.Subject == "":
+		return nil, errors.New("token has no subject")
+	}
+	return &claims, nil
+}
+
+// decodeJWTPart decodes a base64url-encoded JSON part of a JWT.
+func decodeJWTPart(part string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// verifySignature verifies a JWS signature of the digest with a public key.
+func verifySignature(publicKey crypto.PublicKey, algorithm jwtAlgorithm, digest, signature []byte) bool {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if algorithm.pss {
+			return rsa.VerifyPSS(key, algorithm.hash, digest, signature, nil) == nil
+		}
+		return rsa.VerifyPKCS1v15(key, algorithm.hash, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		// ECDSA signatures are the concatenated big-endian r and s, each as long as the curve size
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(key, digest, r, s)
+	default:
+		return false
+	}
+}
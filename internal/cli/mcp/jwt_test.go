@@ -0,0 +1,262 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks := map[string]any{"keys": []map[string]any{
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+		{"kty": "EC", "kid": "p521", "crv": "P-521", "x": b64(p521Key.X.FillBytes(make([]byte, 66))), "y": b64(p521Key.Y.FillBytes(make([]byte, 66)))},
+		{"kty": "RSA", "kid": "encryption", "use": "enc", "n": b64(otherKey.N.Bytes()), "e": "AQAB"},
+		{"kty": "oct", "kid": "symmetric", "k": "c2VjcmV0"},
+	}}
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := LoadJWKS(path, JWTOptions{Issuer: "https://issuer.example.com", Audience: "actions-mcp"})
+	if err != nil {
+		t.Fatalf("LoadJWKS() unexpected error: %v", err)
+	}
+	now := time.Unix(1_800_000_000, 0)
+	authenticator.now = func() time.Time { return now }
+
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"sub":   "alice",
+			"iss":   "https://issuer.example.com",
+			"aud":   []string{"other", "actions-mcp"},
+			"exp":   now.Add(time.Hour).Unix(),
+			"scope": "mcp:tools openid",
+		}
+		for key, value := range overrides {
+			if value == nil {
+				delete(c, key)
+			} else {
+				c[key] = value
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{name: "RS256", token: signJWT(t, "RS256", "rsa", rsaKey, claims(nil))},
+		{name: "PS384", token: signJWT(t, "PS384", "rsa", rsaKey, claims(nil))},
+		{name: "ES256", token: signJWT(t, "ES256", "ec", ecKey, claims(nil))},
+		{name: "ES512", token: signJWT(t, "ES512", "p521", p521Key, claims(nil))},
+		{name: "without key ID", token: signJWT(t, "RS256", "", rsaKey, claims(nil))},
+		{name: "single audience", token: signJWT(t, "RS256", "rsa", rsaKey, claims(map[string]any{"aud": "actions-mcp"}))},
+		{name: "expiry within leeway", token: signJWT(t, "RS256", "rsa", rsaKey, claims(map[string]any{"exp": now.Add(-30 * time.Second).Unix()}))},
+		{name: "unknown key", token: signJWT(t, "RS256", "rsa", otherKey, claims(nil)), wantErr: "invalid signature"},
+		{name: "encryption key", token: signJWT(t, "RS256", "encryption", otherKey, claims(nil)), wantErr: "invalid signature"},
+		{name: "wrong key ID", token: signJWT(t, "RS256", "ec", rsaKey, claims(nil)), wantErr: "invalid signature"},
+		{name: "algorithm for another curve", token: signJWT(t, "ES256", "p521", p521Key, claims(nil)), wantErr: "invalid signature"},
+		{name: "algorithm for another curve without key ID", token: signJWT(t, "ES256", "", p521Key, claims(nil)), wantErr: "invalid signature"},
+		{name: "tampered claims", token: tamper(signJWT(t, "RS256", "rsa", rsaKey, claims(nil))), wantErr: "invalid signature"},
+		{name: "symmetric algorithm", token: unsignedJWT("HS256", claims(nil)), wantErr: "unsupported algorithm 'HS256'"},
+		{name: "none algorithm", token: unsignedJWT("none", claims(nil)), wantErr: "unsupported algorithm 'none'"},
+		{name: "expired", token: signJWT(t, "RS256", "rsa", rsaKey, claims(map[string]any{"exp": now.Add(-time.Hour).Unix()})), wantErr: "token expired"},
+		{name: "no expiration", token: signJWT(t, "RS256", "rsa", rsaKey, claims(map[string]any{"exp": nil})), wantErr: "no expiration"},
+		{name: "not valid yet", token: signJWT(t, "RS256", "rsa", rsaKey, claims(map[string]any{"nbf": now.Add(time.Hour).Unix()})), wantErr: "not valid yet"},
+		{name: "wrong issuer", token: signJWT(t, "RS256", "rsa", rsaKey, claims(map[string]any{"iss": "https://evil.example.com"})), wantErr: "unexpected issuer"},
+		{name: "wrong audience", token: signJWT(t, "RS256", "rsa", rsaKey, claims(map[string]any{"aud": "other"})), wantErr: "audience"},
+		{name: "no subject", token: signJWT(t, "RS256", "rsa", rsaKey, claims(map[string]any{"sub": nil})), wantErr: "no subject"},
+		{name: "API key", token: "s3cr3t", wantErr: "malformed JWT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := authenticator.Authenticate(context.Background(), tt.token)
+			if tt.wantErr != "" {
+				if !errors.Is(err, auth.ErrInvalidToken) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Authenticate() error = %v, want auth.ErrInvalidToken containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() unexpected error: %v", err)
+			}
+			if principal.Name != "alice" || !slices.Equal(principal.Scopes, []string{"mcp:tools", "openid"}) || principal.Expiration.IsZero() {
+				t.Errorf("Authenticate() = %+v, want principal alice with scopes and expiration", principal)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticator_ToolsFromScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		scope  string
+		want   []string
+	}{
+		{name: "tools", scope: "openid mcp:tool:get_readme mcp:tool:audit_action", want: []string{"get_readme", "audit_action"}},
+		{name: "all tools", scope: "mcp:tool:get_readme mcp:tool:*", want: nil},
+		{name: "no tools", scope: "openid mcp:tool: mcp:tools", want: []string{}},
+		{name: "custom prefix", prefix: "actions/", scope: "actions/get_readme mcp:tool:audit_action", want: []string{"get_readme"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, _ := json.Marshal(map[string]any{"keys": []map[string]any{
+				{"kty": "RSA", "n": b64(key.N.Bytes()), "e": b64(big.NewInt(int64(key.E)).Bytes())},
+			}})
+			path := filepath.Join(t.TempDir(), "jwks.json")
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			authenticator, err := LoadJWKS(path, JWTOptions{ToolScopePrefix: tt.prefix})
+			if err != nil {
+				t.Fatalf("LoadJWKS() unexpected error: %v", err)
+			}
+
+			token := signJWT(t, "RS256", "", key, map[string]any{"sub": "alice", "exp": time.Now().Add(time.Hour).Unix(), "scope": tt.scope})
+			principal, err := authenticator.Authenticate(context.Background(), token)
+			if err != nil {
+				t.Fatalf("Authenticate() unexpected error: %v", err)
+			}
+			if !slices.Equal(principal.Tools, tt.want) || (principal.Tools == nil) != (tt.want == nil) {
+				t.Errorf("Authenticate() tools = %#v, want %#v", principal.Tools, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadJWKS_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "invalid JSON", content: "{", wantErr: "failed to parse JWKS"},
+		{name: "no signing keys", content: `{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`, wantErr: "no RSA or EC signing keys"},
+		{name: "invalid modulus", content: `{"keys": [{"kty": "RSA", "kid": "a", "n": "", "e": "AQAB"}]}`, wantErr: "invalid modulus"},
+		{name: "small RSA key", content: `{"keys": [{"kty": "RSA", "kid": "a", "n": "` + b64(bytes.Repeat([]byte{0xff}, 128)) + `", "e": "AQAB"}]}`, wantErr: "at least 2048 are required"},
+		{name: "even exponent", content: `{"keys": [{"kty": "RSA", "kid": "a", "n": "` + b64(bytes.Repeat([]byte{0xff}, 256)) + `", "e": "AQAA"}]}`, wantErr: "invalid exponent"},
+		{name: "exponent overflowing int32", content: `{"keys": [{"kty": "RSA", "kid": "a", "n": "` + b64(bytes.Repeat([]byte{0xff}, 256)) + `", "e": "_____w"}]}`, wantErr: "invalid exponent"},
+		{name: "exponent too small", content: `{"keys": [{"kty": "RSA", "kid": "a", "n": "` + b64(bytes.Repeat([]byte{0xff}, 256)) + `", "e": "AQ"}]}`, wantErr: "invalid exponent"},
+		{name: "unsupported curve", content: `{"keys": [{"kty": "EC", "kid": "a", "crv": "P-192", "x": "AA", "y": "AA"}]}`, wantErr: "unsupported curve"},
+		{name: "point not on curve", content: `{"keys": [{"kty": "EC", "kid": "a", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`, wantErr: "invalid key #1 (a)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "jwks.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadJWKS(path, JWTOptions{}); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadJWKS() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// b64 encodes data as unpadded base64url, as used by JWTs and JWKs.
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signJWT creates a JWT with the given claims, signed with an RSA or ECDSA private key.
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+
+	header := map[string]any{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		header["kid"] = kid
+	}
+	headerJSON, _ := json.Marshal(header)
+	claimsJSON, _ := json.Marshal(claims)
+	signingInput := b64(headerJSON) + "." + b64(claimsJSON)
+
+	algorithm := jwtAlgorithms[alg]
+	hasher := algorithm.hash.New()
+	hasher.Write([]byte(signingInput))
+	digest := hasher.Sum(nil)
+
+	var signature []byte
+	var err error
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		if algorithm.pss {
+			signature, err = rsa.SignPSS(rand.Reader, key, algorithm.hash, digest, nil)
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, key, algorithm.hash, digest)
+		}
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, key, digest)
+		if err == nil {
+			size := (key.Curve.Params().BitSize + 7) / 8
+			signature = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingInput + "." + b64(signature)
+}
+
+// unsignedJWT creates a JWT with the given algorithm in its header and an empty signature.
+func unsignedJWT(alg string, claims map[string]any) string {
+	headerJSON, _ := json.Marshal(map[string]any{"alg": alg})
+	claimsJSON, _ := json.Marshal(claims)
+	return b64(headerJSON) + "." + b64(claimsJSON) + "."
+}
+
+// tamper replaces the claims of a JWT, keeping its signature.
+func tamper(token string) string {
+	parts := strings.Split(token, ".")
+	claimsJSON, _ := json.Marshal(map[string]any{"sub": "mallory", "exp": time.Now().Add(time.Hour).Unix()})
+	return parts[0] + "." + b64(claimsJSON) + "." + parts[2]
+}
@@ -0,0 +1,295 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+	"github.com/techprimate/github-actions-utils-cli/internal/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// maxExplainedActions is the number of distinct actions whose descriptions are fetched for explain_workflow.
+const maxExplainedActions = 20
+
+// promptTools maps each prompt to the tool returning the data it embeds.
+// Principals restricted to some tools may only use the prompts of those tools.
+var promptTools = map[string]string{
+	"write_step_for_action": "get_action_parameters",
+	"upgrade_action":        "diff_action_versions",
+	"explain_workflow":      "inventory_workflow_actions",
+}
+
+// registerPrompts registers prompts for common workflow-authoring tasks. Each prompt embeds live data
+// from the ActionsService, such as the inputs of an action, so that clients offer them as one-click flows.
+func (m *MCPServer) registerPrompts(server *mcp.Server) {
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "write_step_for_action",
+		Title:       "Write a step for an action",
+		Description: "Write a workflow step using a GitHub Action, starting from a step template with all inputs the action declares.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "actionRef", Description: "The action, e.g. 'actions/setup-node@v4' or 'actions/setup-node@latest'", Required: true},
+			{Name: "goal", Description: "What the step should achieve, e.g. 'install Node.js 22 with npm caching'"},
+		},
+	}, m.handleWriteStepForActionPrompt)
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "upgrade_action",
+		Title:       "Upgrade an action",
+		Description: "Upgrade the uses of a GitHub Action in a workflow to a newer version, given the changes of its inputs, outputs and runtime between both versions.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "actionRef", Description: "The action at the version currently used, e.g. 'docker/build-push-action@v5'", Required: true},
+			{Name: "toVersion", Description: "The version to upgrade to, e.g. 'v6' or '^6'. Defaults to the latest release."},
+		},
+	}, m.handleUpgradeActionPrompt)
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "explain_workflow",
+		Title:       "Explain a workflow",
+		Description: "Explain what a GitHub Actions workflow does, given its content and the descriptions of the actions it uses.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "workflowRef", Description: "The workflow, e.g. 'owner/repo/.github/workflows/ci.yml@main' or './.github/workflows/ci.yml' in the workspace configured on the server", Required: true},
+		},
+	}, m.handleExplainWorkflowPrompt)
+}
+
+// handleWriteStepForActionPrompt handles the write_step_for_action prompt.
+func (m *MCPServer) handleWriteStepForActionPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	actionRef := req.Params.Arguments["actionRef"]
+	if actionRef == "" {
+		return nil, invalidArgument("actionRef is required")
+	}
+
+	metadata, resolved, err := m.actionsService.GetActionParameters(ctx, actionRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get action parameters: %w", err)
+	}
+
+	// Reference version specs such as "latest" by the release they resolved to
+	uses := actionRef
+	if ref, err := github.ParseActionRef(actionRef); err == nil && resolved != nil && github.IsVersionSpec(ref.Version) {
+		ref.Version = resolved.Version
+		uses = ref.String()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Write a GitHub Actions workflow step that uses the action %s", uses)
+	if goal := req.Params.Arguments["goal"]; goal != "" {
+		fmt.Fprintf(&b, " to %s", goal)
+	}
+	b.WriteString(".\n\n")
+	if metadata.Description != "" {
+		fmt.Fprintf(&b, "The action is described as: %s\n\n", strings.TrimSpace(metadata.Description))
+	}
+	b.WriteString("Start from this template, generated from the action's action.yml. Required inputs are listed first; set them, ")
+	b.WriteString("and uncomment optional inputs only if they are needed. Do not add inputs which are not listed.\n\n")
+	fmt.Fprintf(&b, "```yaml\n%s```\n", formatStepTemplate(uses, metadata))
+	if len(metadata.Outputs) > 0 {
+		b.WriteString("\nThe action sets these outputs, which later steps can read as ${{ steps.<id>.outputs.<name> }} if the step has an id:\n")
+		for _, name := range slices.Sorted(maps.Keys(metadata.Outputs)) {
+			fmt.Fprintf(&b, "  - %s", name)
+			if description := firstLine(metadata.Outputs[name].Description); description != "" {
+				fmt.Fprintf(&b, ": %s", description)
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\nReply with the step and a short explanation of the inputs you set.")
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Write a step using %s", uses),
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: b.String()}},
+		},
+	}, nil
+}
+
+// handleUpgradeActionPrompt handles the upgrade_action prompt.
+func (m *MCPServer) handleUpgradeActionPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	actionRef := req.Params.Arguments["actionRef"]
+	if actionRef == "" {
+		return nil, invalidArgument("actionRef is required")
+	}
+	ref, err := github.ParseActionRef(actionRef)
+	if err != nil {
+		return nil, invalidArgument("invalid action reference: %v", err)
+	}
+	fromVersion := ref.Version
+	toVersion := req.Params.Arguments["toVersion"]
+	if toVersion == "" {
+		toVersion = github.VersionLatest
+	}
+
+	action := strings.TrimSuffix(ref.String(), "@"+ref.Version)
+	diff, err := m.actionsService.DiffActionVersions(ctx, action, fromVersion, toVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff action versions: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Upgrade every use of %s in my workflows to %s@%s", actionRef, action, diff.To.Version)
+	if diff.To.SHA != "" {
+		fmt.Fprintf(&b, " (commit %s)", diff.To.SHA)
+	}
+	b.WriteString(".\n\n")
+	b.WriteString("These are the changes of the action's interface between both versions, compared from their action.yml files:\n\n")
+	b.WriteString(diff.String())
+	b.WriteString("\n")
+	if diff.IsBreaking() {
+		b.WriteString("The update is breaking. Rename or remove inputs as listed above, set newly required inputs, and update ")
+		b.WriteString("references to removed outputs in later steps (${{ steps.<id>.outputs.<name> }}).\n")
+	} else {
+		b.WriteString("The update is not breaking; changing the version should be enough, but check the changed defaults above.\n")
+	}
+	b.WriteString("Keep the pinning style of each use: if a use is pinned to a commit SHA, pin the new version to its SHA as well.\n")
+	b.WriteString("\nShow the updated steps and point out anything that needs manual attention.")
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Upgrade %s to %s", actionRef, diff.To.Version),
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: b.String()}},
+		},
+	}, nil
+}
+
+// handleExplainWorkflowPrompt handles the explain_workflow prompt.
+func (m *MCPServer) handleExplainWorkflowPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	workflowRef := req.Params.Arguments["workflowRef"]
+	if workflowRef == "" {
+		return nil, invalidArgument("workflowRef is required")
+	}
+
+	if github.IsLocalRef(workflowRef) {
+		localPath, err := github.ParseLocalRef(workflowRef)
+		if err != nil {
+			return nil, invalidArgument("%v", err)
+		}
+		if !isWorkflowPath(localPath) {
+			return nil, invalidArgument("local workflowRef must reference a .yml or .yaml file in ./.github/workflows, got '%s'", workflowRef)
+		}
+		// Only read from the workspace configured by the operator (--workspace), never from one set for the session
+		ctx = github.WithWorkspace(ctx, "")
+	}
+
+	content, _, err := m.fetchWorkflow(ctx, "", workflowRef)
+	if err != nil {
+		return nil, err
+	}
+	// The content is embedded as is, so files which are not workflows are rejected without
+	// repeating parse errors, which may quote the content
+	parsed, err := workflow.Parse([]byte(content))
+	if err != nil || len(parsed.Jobs) == 0 {
+		return nil, invalidArgument("'%s' is not a valid workflow", workflowRef)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Explain what the GitHub Actions workflow %s does: when it runs, what each job does and how the jobs depend on each other. ", workflowRef)
+	b.WriteString("Point out anything unusual, such as missing permissions, actions not pinned to a version or secrets passed to third-party actions.\n\n")
+	fmt.Fprintf(&b, "```yaml\n%s\n```\n", strings.TrimRight(content, "\n"))
+
+	// Describe the actions used by the workflow, each fetched once
+	described := map[string]bool{}
+	var descriptions []string
+	for _, reference := range parsed.Inventory() {
+		if reference.Kind != workflow.KindAction || reference.Ref == nil || described[reference.Uses] {
+			continue
+		}
+		described[reference.Uses] = true
+		if len(described) > maxExplainedActions {
+			break
+		}
+
+		metadata, _, err := m.actionsService.GetActionParameters(ctx, reference.Uses)
+		if err != nil {
+			m.logger.Debug("failed to describe action", "action", reference.Uses, "error", err)
+			descriptions = append(descriptions, fmt.Sprintf("  - %s: could not be fetched (%v)", reference.Uses, err))
+			continue
+		}
+		description := fmt.Sprintf("  - %s: %s", reference.Uses, metadata.Name)
+		if line := firstLine(metadata.Description); line != "" {
+			description += " - " + line
+		}
+		descriptions = append(descriptions, description)
+	}
+	if len(descriptions) > 0 {
+		b.WriteString("\nThe actions it uses are described in their action.yml files as:\n")
+		b.WriteString(strings.Join(descriptions, "\n"))
+		b.WriteString("\n")
+	}
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Explain the workflow %s", workflowRef),
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: b.String()}},
+		},
+	}, nil
+}
+
+// isWorkflowPath reports whether a slash-separated path relative to the workspace is a workflow,
+// i.e. a .yml or .yaml file directly in .github/workflows.
+func isWorkflowPath(localPath string) bool {
+	dir, file := path.Split(localPath)
+	ext := path.Ext(file)
+	return dir == ".github/workflows/" && (ext == ".yml" || ext == ".yaml") && file != ext
+}
+
+// formatStepTemplate formats a workflow step using an action, with all inputs it declares:
+// required inputs without default first, followed by the optional inputs commented out with their
+// defaults. Deprecated inputs are left out.
+func formatStepTemplate(uses string, metadata *github.ActionMetadata) string {
+	var required, optional []string
+	for _, name := range slices.Sorted(maps.Keys(metadata.Inputs)) {
+		input := metadata.Inputs[name]
+		if input.DeprecationMessage != "" {
+			continue
+		}
+		var lines []string
+		if description := firstLine(input.Description); description != "" {
+			lines = append(lines, "    # "+description)
+		}
+		if input.Required && input.Default == "" {
+			required = append(required, strings.Join(append(lines, "    "+name+": # required"), "\n"))
+		} else {
+			optional = append(optional, strings.Join(append(lines, "    # "+name+": "+yamlScalar(input.Default)), "\n"))
+		}
+	}
+
+	var b strings.Builder
+	if name := strings.TrimSpace(metadata.Name); name != "" {
+		fmt.Fprintf(&b, "- name: %s\n", yamlScalar(name))
+		fmt.Fprintf(&b, "  uses: %s\n", uses)
+	} else {
+		fmt.Fprintf(&b, "- uses: %s\n", uses)
+	}
+	if inputs := append(required, optional...); len(inputs) > 0 {
+		b.WriteString("  with:\n")
+		b.WriteString(strings.Join(inputs, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// yamlScalar formats a string as a YAML scalar, quoting it if needed.
+func yamlScalar(value string) string {
+	if value == "" {
+		return "''"
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return value
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// firstLine returns the first non-empty line of a description, trimmed.
+func firstLine(description string) string {
+	for line := range strings.Lines(description) {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
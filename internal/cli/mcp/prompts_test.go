@@ -0,0 +1,241 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// newPromptTestService creates an ActionsService serving two versions of example/setup, and a
+// workspace with a workflow using it.
+func newPromptTestService(t *testing.T) *github.ActionsService {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/raw/example/setup/") && strings.HasSuffix(r.URL.Path, "/v1/action.yml"):
+			w.Write([]byte(`name: Setup Example
+description: Set up the example toolchain
+inputs:
+  version:
+    description: The version to install
+    required: true
+  cache:
+    description: Whether to cache downloads
+    default: "true"
+outputs:
+  path:
+    description: The installation directory
+runs:
+  using: node20
+  main: index.js
+`))
+		case strings.HasPrefix(r.URL.Path, "/raw/example/setup/") && strings.HasSuffix(r.URL.Path, "/v2/action.yml"):
+			w.Write([]byte(`name: Setup Example
+inputs:
+  toolchain-version:
+    description: The version to install
+    required: true
+runs:
+  using: node24
+  main: index.js
+`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	workspace := t.TempDir()
+	workflowDir := filepath.Join(workspace, ".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	workflow := "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: example/setup@v1\n      - uses: example/missing@v1\n      - uses: example/setup@v1\n"
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), []byte(workflow), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workflowDir, "settings.yml"), []byte("token: s3cr3t\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workspace, "credentials.yml"), []byte("on: push\njobs:\n  leak:\n    runs-on: s3cr3t\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return github.NewActionsService(&github.ActionsServiceOptions{
+		Endpoints: &github.Endpoints{
+			WebURL: server.URL,
+			APIURL: server.URL + "/api/v3",
+			RawURL: server.URL + "/raw",
+		},
+		Workspace: workspace,
+	})
+}
+
+func TestPrompts(t *testing.T) {
+	session := connectTestClient(t, newPromptTestService(t))
+
+	tests := []struct {
+		name      string
+		prompt    string
+		arguments map[string]string
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:      "write step",
+			prompt:    "write_step_for_action",
+			arguments: map[string]string{"actionRef": "example/setup@v1", "goal": "install version 3"},
+			want: []string{
+				"uses the action example/setup@v1 to install version 3",
+				"Set up the example toolchain",
+				"    version: # required",
+				`    # cache: "true"`,
+				"  - path: The installation directory",
+			},
+		},
+		{
+			name:      "upgrade action",
+			prompt:    "upgrade_action",
+			arguments: map[string]string{"actionRef": "example/setup@v1", "toVersion": "v2"},
+			want:      []string{"to example/setup@v2", "toolchain-version", "The update is breaking"},
+		},
+		{
+			name:      "explain workflow",
+			prompt:    "explain_workflow",
+			arguments: map[string]string{"workflowRef": "./.github/workflows/ci.yml"},
+			want: []string{
+				"- uses: example/setup@v1",
+				"  - example/setup@v1: Setup Example - Set up the example toolchain",
+				"  - example/missing@v1: could not be fetched",
+			},
+		},
+		{name: "local file outside of workflows", prompt: "explain_workflow", arguments: map[string]string{"workflowRef": "./credentials.yml"}, wantErr: "must reference a .yml or .yaml file in ./.github/workflows"},
+		{name: "local path escaping workflows", prompt: "explain_workflow", arguments: map[string]string{"workflowRef": "./.github/workflows/../../credentials.yml"}, wantErr: "must reference a .yml or .yaml file in ./.github/workflows"},
+		{name: "local file which is not a workflow", prompt: "explain_workflow", arguments: map[string]string{"workflowRef": "./.github/workflows/settings.yml"}, wantErr: "is not a valid workflow"},
+		{name: "missing argument", prompt: "write_step_for_action", arguments: map[string]string{}, wantErr: "actionRef is required"},
+		{name: "invalid reference", prompt: "upgrade_action", arguments: map[string]string{"actionRef": "example"}, wantErr: "invalid action reference"},
+		{name: "action not found", prompt: "write_step_for_action", arguments: map[string]string{"actionRef": "example/missing@v1"}, wantErr: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{Name: tt.prompt, Arguments: tt.arguments})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetPrompt(%q) error = %v, want it to contain %q", tt.prompt, err, tt.wantErr)
+				}
+				if strings.Contains(err.Error(), "s3cr3t") {
+					t.Errorf("GetPrompt(%q) error = %v, want it not to contain the content of the file", tt.prompt, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPrompt(%q) unexpected error: %v", tt.prompt, err)
+			}
+			if len(result.Messages) != 1 || result.Messages[0].Role != "user" {
+				t.Fatalf("GetPrompt(%q) = %+v, want a single user message", tt.prompt, result.Messages)
+			}
+			text := result.Messages[0].Content.(*mcp.TextContent).Text
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("GetPrompt(%q) text = %q, want it to contain %q", tt.prompt, text, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExplainWorkflowPrompt_IgnoresRoots(t *testing.T) {
+	rootDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rootDir, ".github", "workflows"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rootDir, ".github", "workflows", "ci.yml"), []byte("on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	root := &mcp.Root{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(rootDir)}).String()}
+	session := connectTestClient(t, github.NewActionsService(nil), root)
+
+	_, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{
+		Name:      "explain_workflow",
+		Arguments: map[string]string{"workflowRef": "./.github/workflows/ci.yml"},
+	})
+	if err == nil || !strings.Contains(err.Error(), github.ErrNoWorkspace.Error()) {
+		t.Errorf("GetPrompt() error = %v, want it to require a configured workspace", err)
+	}
+}
+
+func TestListPrompts(t *testing.T) {
+	session := connectTestClient(t, github.NewActionsService(nil))
+
+	result, err := session.ListPrompts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListPrompts() unexpected error: %v", err)
+	}
+	var names []string
+	for _, prompt := range result.Prompts {
+		names = append(names, prompt.Name)
+		if _, ok := promptTools[prompt.Name]; !ok {
+			t.Errorf("prompt %q has no entry in promptTools", prompt.Name)
+		}
+	}
+	slices.Sort(names)
+	if want := []string{"explain_workflow", "upgrade_action", "write_step_for_action"}; !slices.Equal(names, want) {
+		t.Errorf("ListPrompts() = %v, want %v", names, want)
+	}
+}
+
+func TestFormatStepTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *github.ActionMetadata
+		want     string
+	}{
+		{
+			name:     "no inputs",
+			metadata: &github.ActionMetadata{Name: "Cleanup"},
+			want:     "- name: Cleanup\n  uses: example/action@v1\n",
+		},
+		{
+			name: "required, optional and deprecated inputs",
+			metadata: &github.ActionMetadata{
+				Name: "Build: all",
+				Inputs: map[string]github.ActionInput{
+					"tags":   {Description: "List of tags\nOne per line", Required: true},
+					"push":   {Description: "Push the image", Default: "false"},
+					"load":   {DeprecationMessage: "Use outputs instead"},
+					"token":  {Required: true, Default: "${{ github.token }}"},
+					"labels": {},
+				},
+			},
+			want: `- name: 'Build: all'
+  uses: example/action@v1
+  with:
+    # List of tags
+    tags: # required
+    # labels: ''
+    # Push the image
+    # push: "false"
+    # token: ${{ github.token }}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatStepTemplate("example/action@v1", tt.metadata); got != tt.want {
+				t.Errorf("formatStepTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// URI prefixes of the resources served by the server (see registerResources).
+const (
+	ActionResourcePrefix = "gha://action/"
+	ReadmeResourcePrefix = "gha://readme/"
+)
+
+// MIME types of the resources served by the server.
+const (
+	MIMETypeYAML     = "application/yaml"
+	MIMETypeMarkdown = "text/markdown"
+)
+
+// resourceTools maps the URI prefix of each resource to the tool returning the same information.
+// Principals restricted to some tools may only read the resources of those tools.
+var resourceTools = map[string]string{
+	ActionResourcePrefix: "get_action_parameters",
+	ReadmeResourcePrefix: "get_readme",
+}
+
+// resourceTool returns the tool returning the same information as the resource or resource template
+// with the given URI, or an empty string if there is none.
+func resourceTool(uri string) string {
+	for prefix, tool := range resourceTools {
+		if strings.HasPrefix(uri, prefix) {
+			return tool
+		}
+	}
+	return ""
+}
+
+// registerResources registers the resource templates exposing action definitions and READMEs as
+// read-only documents, so that clients can attach them as context without a tool call.
+func (m *MCPServer) registerResources(server *mcp.Server) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "action",
+		Title:       "GitHub Action definition",
+		URITemplate: ActionResourcePrefix + "{owner}/{repo}{/path*}@{ref}",
+		MIMEType:    MIMETypeYAML,
+		Description: "The action.yml of a GitHub Action, e.g. gha://action/actions/checkout@v5 or gha://action/github/codeql-action/init@v3 for actions in a subdirectory. The ref can be a tag, branch or commit SHA, or a version spec such as 'latest' or '^4' (percent-encoded as %5E4).",
+	}, m.handleActionResource)
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "readme",
+		Title:       "Repository README",
+		URITemplate: ReadmeResourcePrefix + "{owner}/{repo}@{ref}",
+		MIMEType:    MIMETypeMarkdown,
+		Description: "The README of a GitHub repository at a tag, branch or commit SHA, e.g. gha://readme/actions/checkout@main.",
+	}, m.handleReadmeResource)
+}
+
+// handleActionResource serves the action.yml of the action referenced by a gha://action/ URI.
+func (m *MCPServer) handleActionResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	actionRef, err := resourceRef(uri, ActionResourcePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := m.actionsService.GetActionYAML(ctx, actionRef)
+	if err != nil {
+		return nil, resourceError(uri, err)
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{URI: uri, MIMEType: MIMETypeYAML, Text: string(data)},
+		},
+	}, nil
+}
+
+// handleReadmeResource serves the README of the repository referenced by a gha://readme/ URI.
+func (m *MCPServer) handleReadmeResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	repoRef, err := resourceRef(uri, ReadmeResourcePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := m.actionsService.GetReadme(ctx, repoRef)
+	if err != nil {
+		return nil, resourceError(uri, err)
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{URI: uri, MIMEType: MIMETypeMarkdown, Text: content},
+		},
+	}, nil
+}
+
+// resourceRef converts a resource URI such as "gha://action/actions/checkout@v5" to the
+// reference "actions/checkout@v5". Percent-encoded characters, e.g. "%5E4" for "^4", are decoded.
+func resourceRef(uri, prefix string) (string, error) {
+	ref, err := url.PathUnescape(strings.TrimPrefix(uri, prefix))
+	if err != nil || !strings.HasPrefix(uri, prefix) {
+		return "", fmt.Errorf("invalid resource URI '%s'", uri)
+	}
+	return ref, nil
+}
+
+// resourceError converts an error of the ActionsService to the error returned for a resource:
+// references that do not exist are reported as resource not found.
+func resourceError(uri string, err error) error {
+	if errors.Is(err, github.ErrNotFound) {
+		return mcp.ResourceNotFoundError(uri)
+	}
+	return fmt.Errorf("failed to read %s: %w", uri, err)
+}
@@ -0,0 +1,104 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// newResourceTestService creates an ActionsService serving the action.yml and README of
+// actions/checkout and the action.yml of github/codeql-action/init at any ref.
+func newResourceTestService(t *testing.T) *github.ActionsService {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/raw/actions/checkout/") && strings.HasSuffix(r.URL.Path, "/action.yml"):
+			w.Write([]byte("name: Checkout\nruns:\n  using: node24\n  main: dist/index.js\n"))
+		case strings.HasPrefix(r.URL.Path, "/raw/github/codeql-action/") && strings.HasSuffix(r.URL.Path, "/init/action.yml"):
+			w.Write([]byte("name: CodeQL Init\nruns:\n  using: node24\n  main: ../lib/init-action.js\n"))
+		case strings.HasPrefix(r.URL.Path, "/raw/actions/checkout/") && strings.HasSuffix(r.URL.Path, "/README.md"):
+			w.Write([]byte("# Checkout V5\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return github.NewActionsService(&github.ActionsServiceOptions{
+		Endpoints: &github.Endpoints{
+			WebURL: server.URL,
+			APIURL: server.URL + "/api/v3",
+			RawURL: server.URL + "/raw",
+		},
+	})
+}
+
+func TestResources(t *testing.T) {
+	session := connectTestClient(t, newResourceTestService(t))
+
+	tests := []struct {
+		name         string
+		uri          string
+		wantMIMEType string
+		want         string
+		wantErr      string
+	}{
+		{name: "action", uri: "gha://action/actions/checkout@v5", wantMIMEType: MIMETypeYAML, want: "name: Checkout"},
+		{name: "action in subdirectory", uri: "gha://action/github/codeql-action/init@v3", wantMIMEType: MIMETypeYAML, want: "name: CodeQL Init"},
+		{name: "percent-encoded branch", uri: "gha://action/actions/checkout@releases%2Fv5", wantMIMEType: MIMETypeYAML, want: "name: Checkout"},
+		{name: "readme", uri: "gha://readme/actions/checkout@main", wantMIMEType: MIMETypeMarkdown, want: "# Checkout V5"},
+		{name: "action not found", uri: "gha://action/actions/missing@v1", wantErr: "not found"},
+		{name: "readme not found", uri: "gha://readme/actions/missing@main", wantErr: "not found"},
+		{name: "unknown resource", uri: "gha://workflow/actions/checkout@v5", wantErr: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: tt.uri})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReadResource(%q) error = %v, want it to contain %q", tt.uri, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadResource(%q) unexpected error: %v", tt.uri, err)
+			}
+			if len(result.Contents) != 1 {
+				t.Fatalf("ReadResource(%q) returned %d contents, want 1", tt.uri, len(result.Contents))
+			}
+			contents := result.Contents[0]
+			if contents.URI != tt.uri || contents.MIMEType != tt.wantMIMEType || !strings.HasPrefix(contents.Text, tt.want) {
+				t.Errorf("ReadResource(%q) = %+v, want MIME type %q and text starting with %q", tt.uri, contents, tt.wantMIMEType, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceTemplates(t *testing.T) {
+	session := connectTestClient(t, github.NewActionsService(nil))
+
+	result, err := session.ListResourceTemplates(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListResourceTemplates() unexpected error: %v", err)
+	}
+	mimeTypes := map[string]string{}
+	for _, template := range result.ResourceTemplates {
+		mimeTypes[template.URITemplate] = template.MIMEType
+	}
+	want := map[string]string{
+		"gha://action/{owner}/{repo}{/path*}@{ref}": MIMETypeYAML,
+		"gha://readme/{owner}/{repo}@{ref}":         MIMETypeMarkdown,
+	}
+	for uriTemplate, mimeType := range want {
+		if mimeTypes[uriTemplate] != mimeType {
+			t.Errorf("ListResourceTemplates() MIME type of %q = %q, want %q", uriTemplate, mimeTypes[uriTemplate], mimeType)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// sessionWorkspaces caches the workspace derived from the roots of each client session,
+// so that the roots are not listed again for every tool call.
+type sessionWorkspaces struct {
+	mu         sync.Mutex
+	workspaces map[*mcp.ServerSession]sessionWorkspace
+}
+
+// sessionWorkspace is the workspace of a session. It is stale once the client changed its roots.
+type sessionWorkspace struct {
+	dir   string
+	stale bool
+}
+
+// WorkspaceMiddleware resolves local references ("./...") in tool calls against the roots
+// provided by the client, unless the ActionsService was configured with a workspace (--workspace).
+// The first root with a file:// URI is used. Clients without roots can still use local
+// references if the server has a workspace; otherwise they fail with ErrorCodeNoWorkspace.
+//
+// Roots are paths on the client's host, so the middleware must only be used when the client runs
+// on the same host as the server, i.e. over stdio.
+func (m *MCPServer) WorkspaceMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method == "tools/call" && m.actionsService.Workspace(ctx) == "" {
+			if session, ok := req.GetSession().(*mcp.ServerSession); ok {
+				if workspace := m.sessionWorkspace(ctx, session); workspace != "" {
+					ctx = github.WithWorkspace(ctx, workspace)
+				}
+			}
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// HandleRootsListChanged forgets the workspace of a session whose client changed its roots,
+// so that they are listed again on the next tool call.
+func (m *MCPServer) HandleRootsListChanged(ctx context.Context, req *mcp.RootsListChangedRequest) {
+	m.workspaces.mu.Lock()
+	defer m.workspaces.mu.Unlock()
+	if workspace, ok := m.workspaces.workspaces[req.Session]; ok {
+		workspace.stale = true
+		m.workspaces.workspaces[req.Session] = workspace
+	}
+}
+
+// sessionWorkspace returns the workspace derived from the roots of a session, listing them if needed.
+// Clients which do not support roots are remembered as having no workspace.
+// The workspace is forgotten once the session ends.
+func (m *MCPServer) sessionWorkspace(ctx context.Context, session *mcp.ServerSession) string {
+	m.workspaces.mu.Lock()
+	workspace, known := m.workspaces.workspaces[session]
+	m.workspaces.mu.Unlock()
+	if known && !workspace.stale {
+		return workspace.dir
+	}
+
+	workspace = sessionWorkspace{}
+	result, err := session.ListRoots(ctx, nil)
+	if err != nil {
+		m.logger.Debug("failed to list roots", "error", err)
+	} else {
+		workspace.dir = workspaceFromRoots(result.Roots)
+	}
+
+	m.workspaces.mu.Lock()
+	defer m.workspaces.mu.Unlock()
+	if m.workspaces.workspaces == nil {
+		m.workspaces.workspaces = map[*mcp.ServerSession]sessionWorkspace{}
+	}
+	if _, ok := m.workspaces.workspaces[session]; !ok {
+		// Sessions come and go when serving over HTTP
+		go m.forgetSessionWorkspace(session)
+	}
+	m.workspaces.workspaces[session] = workspace
+	return workspace.dir
+}
+
+// forgetSessionWorkspace removes the workspace of a session from the cache once the session ends.
+func (m *MCPServer) forgetSessionWorkspace(session *mcp.ServerSession) {
+	_ = session.Wait()
+
+	m.workspaces.mu.Lock()
+	defer m.workspaces.mu.Unlock()
+	delete(m.workspaces.workspaces, session)
+}
+
+// workspaceFromRoots returns the directory of the first root with a file:// URI,
+// or an empty string if there is none.
+func workspaceFromRoots(roots []*mcp.Root) string {
+	for _, root := range roots {
+		uri, err := url.Parse(root.URI)
+		if err != nil || uri.Scheme != "file" || uri.Path == "" {
+			continue
+		}
+		dir := uri.Path
+		// file:///C:/path has the drive letter after the leading slash of the path
+		if runtime.GOOS == "windows" {
+			dir = strings.TrimPrefix(dir, "/")
+		}
+		return filepath.FromSlash(dir)
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+func TestWorkspaceFromRoots(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("roots use Unix paths")
+	}
+
+	tests := []struct {
+		name  string
+		roots []*mcp.Root
+		want  string
+	}{
+		{name: "no roots", roots: nil, want: ""},
+		{name: "file root", roots: []*mcp.Root{{URI: "file:///home/user/repo"}}, want: "/home/user/repo"},
+		{name: "escaped path", roots: []*mcp.Root{{URI: "file:///home/user/my%20repo"}}, want: "/home/user/my repo"},
+		{name: "first file root", roots: []*mcp.Root{{URI: "https://example.com"}, {URI: "file:///a"}, {URI: "file:///b"}}, want: "/a"},
+		{name: "invalid URI", roots: []*mcp.Root{{URI: "file://%zz"}}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := workspaceFromRoots(tt.roots); got != tt.want {
+				t.Errorf("workspaceFromRoots() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// connectTestClient connects a client with the given roots to a server using the workspace middleware.
+func connectTestClient(t *testing.T, service *github.ActionsService, roots ...*mcp.Root) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+
+	server := NewMCPServer(service, nil).NewServer(&mcp.Implementation{Name: "test"}, TransportStdio)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	client.AddRoots(roots...)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Connect() unexpected error: %v", err)
+	}
+	t.Cleanup(func() { serverSession.Close() })
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Connect() unexpected error: %v", err)
+	}
+	t.Cleanup(func() { clientSession.Close() })
+	return clientSession
+}
+
+// writeTestAction writes an action.yml with the given name to a new workspace directory.
+func writeTestAction(t *testing.T, name string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "action.yml"), []byte("name: "+name+"\nruns:\n  using: node24\n  main: index.js\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestWorkspaceMiddleware(t *testing.T) {
+	rootDir := writeTestAction(t, "From roots")
+	configuredDir := writeTestAction(t, "From flag")
+	root := &mcp.Root{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(rootDir)}).String()}
+
+	tests := []struct {
+		name     string
+		service  *github.ActionsService
+		roots    []*mcp.Root
+		want     string
+		wantCode string
+	}{
+		{name: "client roots", service: github.NewActionsService(nil), roots: []*mcp.Root{root}, want: "Name: From roots"},
+		{name: "configured workspace takes precedence", service: github.NewActionsService(&github.ActionsServiceOptions{Workspace: configuredDir}), roots: []*mcp.Root{root}, want: "Name: From flag"},
+		{name: "no workspace", service: github.NewActionsService(nil), wantCode: ErrorCodeNoWorkspace},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := connectTestClient(t, tt.service, tt.roots...)
+
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "get_action_parameters",
+				Arguments: map[string]any{"actionRef": "./"},
+			})
+			if err != nil {
+				t.Fatalf("CallTool() unexpected error: %v", err)
+			}
+			if tt.wantCode != "" {
+				if !result.IsError || result.Meta[ErrorCodeKey] != tt.wantCode {
+					t.Errorf("CallTool() = %+v, want an error result with code %q", result, tt.wantCode)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("CallTool() returned an error result: %+v", result.Content[0])
+			}
+			if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, tt.want) {
+				t.Errorf("CallTool() text = %q, want it to contain %q", text, tt.want)
+			}
+		})
+	}
+}
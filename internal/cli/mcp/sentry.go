@@ -23,12 +23,14 @@ const (
 	AttrMCPTransport       = "mcp.transport"
 	AttrNetworkTransport   = "network.transport"
 	AttrNetworkProtocolVer = "network.protocol.version"
+	AttrEndUserID          = "enduser.id"
 
 	// Tool-specific Attributes
 	AttrMCPToolName               = "mcp.tool.name"
 	AttrMCPToolResultIsError      = "mcp.tool.result.is_error"
 	AttrMCPToolResultContentCount = "mcp.tool.result.content_count"
 	AttrMCPToolResultContent      = "mcp.tool.result.content"
+	AttrErrorType                 = "error.type"
 
 	// Request Arguments Prefix
 	AttrMCPRequestArgumentPrefix = "mcp.request.argument"
@@ -38,7 +40,10 @@ const (
 	OriginMCPFunction    = "auto.function.mcp_server"
 	SourceMCPRoute       = "route"
 	TransportStdio       = "stdio"
+	TransportHTTP        = "http"
+	TransportSSE         = "sse"
 	NetworkTransportPipe = "pipe"
+	NetworkTransportTCP  = "tcp"
 	JSONRPCVersion       = "2.0"
 )
 
@@ -76,8 +81,9 @@ func WithSentryTracing[In, Out any](toolName string, handler mcp.ToolHandlerFor[
 		// Set common MCP attributes
 		transaction.SetData(AttrMCPMethodName, "tools/call")
 		transaction.SetData(AttrMCPToolName, toolName)
-		transaction.SetData(AttrMCPTransport, TransportStdio)
-		transaction.SetData(AttrNetworkTransport, NetworkTransportPipe)
+		transport := transportFromContext(ctx)
+		transaction.SetData(AttrMCPTransport, transport)
+		transaction.SetData(AttrNetworkTransport, networkTransport(transport))
 		transaction.SetData(AttrNetworkProtocolVer, JSONRPCVersion)
 
 		// Set Sentry-specific attributes
@@ -89,6 +95,18 @@ func WithSentryTracing[In, Out any](toolName string, handler mcp.ToolHandlerFor[
 			// The CallToolRequest may have metadata we can extract
 			// For now, we'll use reflection to check if there's an ID field
 			setRequestMetadata(transaction, req)
+
+			// Sessions served over streamable HTTP have an ID
+			if req.Session != nil {
+				if sessionID := req.Session.ID(); sessionID != "" {
+					transaction.SetData(AttrMCPSessionID, sessionID)
+				}
+			}
+		}
+
+		// Set the authenticated API key or token subject (HTTP transport)
+		if principal := PrincipalFromContext(ctx); principal != nil {
+			transaction.SetData(AttrEndUserID, principal.Name)
 		}
 
 		// Extract and set tool arguments
@@ -105,6 +123,14 @@ func WithSentryTracing[In, Out any](toolName string, handler mcp.ToolHandlerFor[
 
 			// Capture the error to Sentry with context
 			hub.CaptureException(err)
+		} else if result != nil && result.IsError {
+			// The handler reported the error in the result, e.g. a reference that was not found
+			transaction.Status = sentry.SpanStatusInternalError
+			transaction.SetData(AttrMCPToolResultIsError, true)
+			if code, ok := result.Meta[ErrorCodeKey]; ok {
+				transaction.SetData(AttrErrorType, code)
+			}
+			setResultMetadata(transaction, result)
 		} else {
 			transaction.Status = sentry.SpanStatusOK
 			transaction.SetData(AttrMCPToolResultIsError, false)
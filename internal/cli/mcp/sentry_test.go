@@ -107,6 +107,43 @@ func TestWithSentryTracing_Error(t *testing.T) {
 	sentry.Flush(2 * time.Second)
 }
 
+func TestWithSentryTracing_ErrorResult(t *testing.T) {
+	// Initialize Sentry with a test transport
+	transport := &testTransport{}
+	err := sentry.Init(sentry.ClientOptions{
+		Dsn:       "https://test@test.ingest.sentry.io/123456",
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize Sentry: %v", err)
+	}
+	defer sentry.Flush(2 * time.Second)
+
+	// Create a mock handler that reports the error in its result
+	mockHandler := func(ctx context.Context, req *mcp.CallToolRequest, args MockArgs) (*mcp.CallToolResult, any, error) {
+		return toolError(invalidArgument("name is required")), nil, nil
+	}
+
+	// Wrap with Sentry tracing
+	wrappedHandler := WithSentryTracing("test_tool_error_result", mockHandler)
+
+	// Execute the handler
+	result, data, err := wrappedHandler(context.Background(), &mcp.CallToolRequest{}, MockArgs{})
+
+	// Verify the error result is passed through unchanged
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	if result == nil || !result.IsError {
+		t.Errorf("Expected error result, got: %v", result)
+	}
+	if data != nil {
+		t.Errorf("Expected nil data on error, got: %v", data)
+	}
+
+	sentry.Flush(2 * time.Second)
+}
+
 func TestWithSentryTracing_ArgumentExtraction(t *testing.T) {
 	// Initialize Sentry
 	transport := &testTransport{}
@@ -1,8 +1,11 @@
 package mcp
 
 import (
+	"fmt"
 	"log/slog"
+	"reflect"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/techprimate/github-actions-utils-cli/internal/github"
 )
@@ -12,6 +15,10 @@ import (
 type MCPServer struct {
 	actionsService *github.ActionsService
 	logger         *slog.Logger
+	workspaces     sessionWorkspaces
+
+	// completionOwners are the owners offered when completing references (see SetCompletionOwners).
+	completionOwners []string
 }
 
 // NewMCPServer creates a new MCP server with the given dependencies.
@@ -25,14 +32,141 @@ func NewMCPServer(actionsService *github.ActionsService, logger *slog.Logger) *M
 	}
 }
 
-// RegisterTools registers all available tools with the MCP server.
+// NewServer creates a go-sdk MCP server served over the given transport (e.g. TransportStdio)
+// with all tools registered, and the transport is reported to Sentry. Over stdio, local references
+// are resolved against the client's roots (see WorkspaceMiddleware). Over HTTP, the client runs on
+// another host, so only the workspace configured by the operator (--workspace) is used.
+func (m *MCPServer) NewServer(impl *mcp.Implementation, transport string) *mcp.Server {
+	return m.newServer(impl, transport, nil)
+}
+
+// newServer creates a go-sdk MCP server (see NewServer) whose sessions are authenticated as the
+// given principal, unless requests carry their own (see principalMiddleware).
+func (m *MCPServer) newServer(impl *mcp.Implementation, transport string, principal *Principal) *mcp.Server {
+	options := &mcp.ServerOptions{
+		CompletionHandler: m.handleComplete,
+	}
+	middleware := []mcp.Middleware{transportMiddleware(transport), m.principalMiddleware(principal)}
+	if transport == TransportStdio {
+		options.RootsListChangedHandler = m.HandleRootsListChanged
+		middleware = append([]mcp.Middleware{m.WorkspaceMiddleware}, middleware...)
+	} else {
+		// Close the sessions of HTTP clients which went away without ending them
+		options.KeepAlive = httpKeepAlive
+		// Roots of remote clients are paths on their host, and letting them choose the
+		// directory files are read from would expose the whole file system of the server
+	}
+
+	server := mcp.NewServer(impl, options)
+	m.RegisterTools(server)
+	server.AddReceivingMiddleware(middleware...)
+	return server
+}
+
+// RegisterTools registers all available tools with the MCP server, as well as the
+// resource templates exposing action definitions and READMEs (see registerResources)
+// and the prompts for common workflow-authoring tasks (see registerPrompts).
 func (m *MCPServer) RegisterTools(server *mcp.Server) {
+	m.registerResources(server)
+	m.registerPrompts(server)
+
 	mcp.AddTool(server, &mcp.Tool{
-		Name:        "get_action_parameters",
-		Description: "Fetch and parse a GitHub Action's action.yml file. Returns the complete action.yml structure including inputs, outputs, runs configuration, and metadata.",
+		Name:         "get_action_parameters",
+		Description:  "Fetch and parse a GitHub Action's action.yml file. Actions in a subdirectory are referenced as 'owner/repo/path@version'. The version can be a tag, branch or commit SHA, or a version spec such as 'latest' or '^4' which is resolved to the newest matching release. Docker images used as actions ('docker://alpine:3.20') are accepted as well, and local actions ('./.github/actions/setup') are read from the workspace. For Docker actions, the image, or the base image of the Dockerfile the container is built from, is reported. Returns the complete action.yml structure including inputs, outputs, runs configuration, and metadata, as well as the kind of ref the version was resolved to.",
+		OutputSchema: outputSchemaFor[GetActionParametersResult](),
 	}, WithSentryTracing("get_action_parameters", m.handleGetActionParameters))
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_readme",
 		Description: "Fetch the README.md file from a GitHub repository. Takes a repository reference (e.g., 'owner/repo@main' or 'owner/repo'). If no ref is provided, defaults to 'main' branch.",
 	}, WithSentryTracing("get_readme", m.handleGetReadme))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "list_action_versions",
+		Description:  "List the versions of a GitHub Action, sorted semantically with the newest first. Returns the latest stable release, exact releases (e.g. v4.2.1) and moving tags (e.g. v4) together with the release they point to, each with its commit SHA. Optionally resolves a version spec such as 'latest', '^4', '~4.1' or 'v4' to the exact release and commit SHA. Use it to suggest current versions instead of outdated ones.",
+		OutputSchema: outputSchemaFor[ListActionVersionsResult](),
+	}, WithSentryTracing("list_action_versions", m.handleListActionVersions))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "diff_action_versions",
+		Description:  "Compare the interface of a GitHub Action between two versions, e.g. before updating 'docker/build-push-action' from v5 to v6. Reports added, removed and renamed inputs, changed defaults, newly required inputs, new deprecation messages, added and removed outputs and runtime changes (e.g. node16 to node20), and whether the update is breaking.",
+		OutputSchema: outputSchemaFor[DiffActionVersionsResult](),
+	}, WithSentryTracing("diff_action_versions", m.handleDiffActionVersions))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "pin_actions",
+		Description:  "Pin every action and reusable workflow referenced with 'uses:' in a GitHub Actions workflow (or composite action.yml) to a full commit SHA, e.g. 'actions/checkout@v4' becomes 'actions/checkout@<sha> # v4.2.1'. Takes the workflow YAML content and returns the rewritten content, a unified diff and the status of every reference. Formatting and comments are preserved; local actions, Docker images and references already pinned to a SHA are left unchanged.",
+		OutputSchema: outputSchemaFor[github.PinResult](),
+	}, WithSentryTracing("pin_actions", m.handlePinActions))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "inventory_workflow_actions",
+		Description:  "List every action and reusable workflow referenced with 'uses:' in a GitHub Actions workflow, with the job and step, the line and column, and the parsed reference (owner, repo, path and version). The workflow is given either inline as YAML content or as a reference to a file in a repository, e.g. 'owner/repo/.github/workflows/ci.yml@main', or in the workspace, e.g. './.github/workflows/ci.yml'. Local actions and Docker images are listed with their kind but without a parsed reference.",
+		OutputSchema: outputSchemaFor[InventoryWorkflowActionsResult](),
+	}, WithSentryTracing("inventory_workflow_actions", m.handleInventoryWorkflowActions))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "validate_workflow",
+		Description:  "Validate the 'with:' inputs of every action step in a GitHub Actions workflow against the inputs the action declares. Reports unknown inputs with did-you-mean suggestions (e.g. 'node_version' instead of 'node-version'), missing required inputs, deprecated inputs, and references to step outputs the action does not declare, each with its line and column. The workflow is given either inline as YAML content or as a reference to a file in a repository or the workspace. Local actions are checked if a workspace is known. Use it to check generated workflows before suggesting them.",
+		OutputSchema: outputSchemaFor[ValidateWorkflowResult](),
+	}, WithSentryTracing("validate_workflow", m.handleValidateWorkflow))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "get_reusable_workflow_parameters",
+		Description:  "Fetch and parse a reusable GitHub Actions workflow, referenced as 'owner/repo/.github/workflows/<file>.yml@version' like in a job's uses:, or as './.github/workflows/<file>.yml' for workflows in the workspace. Returns the inputs (with type, whether they are required, and default), secrets and outputs declared by its on.workflow_call trigger, as well as the kind of ref the version was resolved to. The version can be a tag, branch or commit SHA, or a version spec such as 'latest'.",
+		OutputSchema: outputSchemaFor[GetReusableWorkflowParametersResult](),
+	}, WithSentryTracing("get_reusable_workflow_parameters", m.handleGetReusableWorkflowParameters))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "get_action_dependency_tree",
+		Description:  "Recursively resolve the actions a GitHub Action depends on. Composite actions call other actions in their steps; these are fetched recursively and returned as a tree of action references with their runtime (e.g. node20, docker or composite) and the ref each version was resolved to. Cycles are detected and not expanded again, and expansion stops at maxDepth. Use it to audit the transitive supply chain of an action.",
+		OutputSchema: dependencyTreeOutputSchema(),
+	}, WithSentryTracing("get_action_dependency_tree", m.handleGetActionDependencyTree))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "audit_action",
+		Description:  "Audit a GitHub Action for deprecations: a deprecated runtime (runs.using node12, node16 or node20), inputs the action marks as deprecated, and composite steps using the deprecated '::set-output', '::save-state', '::set-env' or '::add-path' workflow commands. If any are found, the newest stable release is audited as well and suggested if it fixes them.",
+		OutputSchema: outputSchemaFor[AuditActionResult](),
+	}, WithSentryTracing("audit_action", m.handleAuditAction))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "get_repository_file",
+		Description:  "Fetch any file from a GitHub repository at a tag, branch or commit SHA, e.g. an action's entry point 'dist/index.js', its 'CHANGELOG.md' or an example workflow. Text files are returned up to maxBytes (256 KiB by default) and marked as truncated if they are longer; binary files are detected and returned without content. Use list_repository_directory to find files.",
+		OutputSchema: outputSchemaFor[GetRepositoryFileResult](),
+	}, WithSentryTracing("get_repository_file", m.handleGetRepositoryFile))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "list_repository_directory",
+		Description:  "List the files and subdirectories of a directory in a GitHub repository at a tag, branch or commit SHA, with their type and size. Defaults to the root directory. Subdirectories are not listed recursively.",
+		OutputSchema: outputSchemaFor[ListRepositoryDirectoryResult](),
+	}, WithSentryTracing("list_repository_directory", m.handleListRepositoryDirectory))
+}
+
+// outputSchemaFor builds the JSON schema advertised as a tool's output schema from the type T.
+// Tools using it return their structured result as "any", so that error results
+// do not carry an empty structured result.
+// It panics if no schema can be inferred, mirroring mcp.AddTool's handling of invalid tools.
+func outputSchemaFor[T any]() *jsonschema.Schema {
+	schema, err := jsonschema.For[T](nil)
+	if err != nil {
+		panic(fmt.Sprintf("outputSchemaFor: %v", err))
+	}
+	return schema
+}
+
+// dependencyNodeDef is the name of the definition of a dependency tree node in the output
+// schema of get_action_dependency_tree.
+const dependencyNodeDef = "dependencyNode"
+
+// dependencyNodeFields has the fields of github.DependencyNode. As a distinct type, the schema of its
+// fields can be inferred while the nested nodes are replaced by a reference to dependencyNodeDef.
+type dependencyNodeFields github.DependencyNode
+
+// dependencyTreeOutputSchema builds the output schema of get_action_dependency_tree. jsonschema.For
+// rejects the recursive github.DependencyNode, so nodes are defined once in $defs and referenced by $ref.
+func dependencyTreeOutputSchema() *jsonschema.Schema {
+	opts := &jsonschema.ForOptions{
+		TypeSchemas: map[reflect.Type]*jsonschema.Schema{
+			reflect.TypeFor[github.DependencyNode](): {Ref: "#/$defs/" + dependencyNodeDef},
+		},
+	}
+	schema, err := jsonschema.For[GetActionDependencyTreeResult](opts)
+	if err != nil {
+		panic(fmt.Sprintf("dependencyTreeOutputSchema: %v", err))
+	}
+	node, err := jsonschema.For[dependencyNodeFields](opts)
+	if err != nil {
+		panic(fmt.Sprintf("dependencyTreeOutputSchema: %v", err))
+	}
+	schema.Defs = map[string]*jsonschema.Schema{dependencyNodeDef: node}
+	return schema
 }
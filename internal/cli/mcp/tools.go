@@ -3,47 +3,80 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+	"github.com/techprimate/github-actions-utils-cli/internal/workflow"
 )
 
 // GetActionParametersArgs defines the parameters for the get_action_parameters tool.
 type GetActionParametersArgs struct {
-	ActionRef string `json:"actionRef" jsonschema:"GitHub Action reference (e.g., 'actions/checkout@v5')"`
+	ActionRef string `json:"actionRef" jsonschema:"GitHub Action reference (e.g., 'actions/checkout@v5', 'actions/checkout@main', 'actions/checkout@<commit-sha>', 'actions/checkout@latest' or 'github/codeql-action/init@v3' for actions in a subdirectory), or a Docker image used as action (e.g., 'docker://alpine:3.20'), or a local action in the workspace (e.g., './.github/actions/setup')"`
+}
+
+// GetActionParametersResult is the structured result of the get_action_parameters tool.
+// It contains the action.yml metadata and the ref the requested version was resolved to.
+type GetActionParametersResult struct {
+	github.ActionMetadata
+	ResolvedRef *github.ResolvedRef  `json:"resolvedRef" jsonschema:"The version and kind of ref (commit, tag, branch, image or local) the action reference was resolved to"`
+	Docker      *github.DockerAction `json:"docker,omitempty" jsonschema:"The image or Dockerfile the container of a Docker action is built from"`
+	DockerError string               `json:"dockerError,omitempty" jsonschema:"Why the image or Dockerfile of a Docker action could not be described"`
+	RateLimit   *github.RateLimit    `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
 }
 
 // handleGetActionParameters handles the get_action_parameters tool call.
 func (m *MCPServer) handleGetActionParameters(ctx context.Context, req *mcp.CallToolRequest, args GetActionParametersArgs) (*mcp.CallToolResult, any, error) {
 	// Validate input
 	if args.ActionRef == "" {
-		return nil, nil, fmt.Errorf("actionRef is required")
+		return toolError(invalidArgument("actionRef is required")), nil, nil
 	}
 
 	// Fetch and parse action parameters
-	params, err := m.actionsService.GetActionParameters(args.ActionRef)
+	metadata, resolved, err := m.actionsService.GetActionParameters(ctx, args.ActionRef)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get action parameters: %w", err)
+		return toolError(fmt.Errorf("failed to get action parameters: %w", err)), nil, nil
 	}
 
 	// Format as text output
-	textOutput := fmt.Sprintf("Action: %s\n\n", args.ActionRef)
+	textOutput := fmt.Sprintf("Action: %s\n", args.ActionRef)
+	textOutput += fmt.Sprintf("Resolved ref: %s (%s)\n\n", resolved.Version, resolved.Type)
 
 	// Add name and description if available
-	if name, ok := params["name"].(string); ok {
-		textOutput += fmt.Sprintf("Name: %s\n", name)
+	if metadata.Name != "" {
+		textOutput += fmt.Sprintf("Name: %s\n", metadata.Name)
+	}
+	if metadata.Description != "" {
+		textOutput += fmt.Sprintf("Description: %s\n", metadata.Description)
 	}
-	if desc, ok := params["description"].(string); ok {
-		textOutput += fmt.Sprintf("Description: %s\n", desc)
+	if metadata.Runs.Using != "" {
+		textOutput += fmt.Sprintf("Runs using: %s\n", metadata.Runs.Using)
 	}
 
-	// Add inputs summary if available
-	if inputs, ok := params["inputs"].(map[string]interface{}); ok {
-		textOutput += fmt.Sprintf("\nInputs: %d defined\n", len(inputs))
+	// Describe the container of Docker actions. The metadata is returned even if the
+	// Dockerfile cannot be fetched or parsed.
+	var docker *github.DockerAction
+	var dockerError string
+	if metadata.Runs.IsDocker() {
+		docker, err = m.actionsService.DescribeDockerAction(ctx, args.ActionRef, resolved, &metadata.Runs)
+		if err != nil {
+			dockerError = err.Error()
+			textOutput += fmt.Sprintf("Docker details unavailable: %s\n", dockerError)
+		} else {
+			textOutput += formatDockerAction(&metadata.Runs, docker)
+		}
 	}
 
-	// Add outputs summary if available
-	if outputs, ok := params["outputs"].(map[string]interface{}); ok {
-		textOutput += fmt.Sprintf("Outputs: %d defined\n", len(outputs))
+	// Add inputs and outputs summary
+	textOutput += fmt.Sprintf("\nInputs: %d defined\n", len(metadata.Inputs))
+	textOutput += fmt.Sprintf("Outputs: %d defined\n", len(metadata.Outputs))
+
+	rateLimit := m.rateLimit()
+	if rateLimit != nil {
+		textOutput += "\n" + formatRateLimit(rateLimit) + "\n"
 	}
 
 	textOutput += "\nFull action.yml structure returned in structured data."
@@ -55,25 +88,65 @@ func (m *MCPServer) handleGetActionParameters(ctx context.Context, req *mcp.Call
 				Text: textOutput,
 			},
 		},
-	}, params, nil
+	}, GetActionParametersResult{
+		ActionMetadata: *metadata,
+		ResolvedRef:    resolved,
+		Docker:         docker,
+		DockerError:    dockerError,
+		RateLimit:      rateLimit,
+	}, nil
+}
+
+// formatDockerAction formats the container configuration of a Docker action as text.
+func formatDockerAction(runs *github.ActionRuns, docker *github.DockerAction) string {
+	text := ""
+	if docker.Image != nil {
+		text += fmt.Sprintf("Image: %s\n", docker.Image)
+	}
+	if dockerfile := docker.Dockerfile; dockerfile != nil {
+		text += fmt.Sprintf("Dockerfile: %s\n", dockerfile.Path)
+		if dockerfile.BaseImage != "" {
+			text += fmt.Sprintf("Base image: %s\n", dockerfile.BaseImage)
+		}
+	}
+
+	entrypoint := runs.Entrypoint
+	if entrypoint == "" && docker.Dockerfile != nil {
+		entrypoint = docker.Dockerfile.Entrypoint
+	}
+	if entrypoint != "" {
+		text += fmt.Sprintf("Entrypoint: %s\n", entrypoint)
+	}
+	if len(runs.Args) > 0 {
+		text += fmt.Sprintf("Args: %s\n", strings.Join(runs.Args, " "))
+	}
+	for _, name := range slices.Sorted(maps.Keys(runs.Env)) {
+		text += fmt.Sprintf("Env: %s=%s\n", name, runs.Env[name])
+	}
+	return text
 }
 
 // GetReadmeArgs defines the parameters for the get_readme tool.
 type GetReadmeArgs struct {
-	RepoRef string `json:"repoRef" jsonschema:"GitHub repository reference (e.g., 'owner/repo@main' or 'owner/repo'). If no ref is provided, defaults to 'main'."`
+	RepoRef string `json:"repoRef" jsonschema:"GitHub repository reference (e.g., 'owner/repo@main', 'owner/repo' or 'owner/repo/path@main' for a README in a subdirectory). If no ref is provided, defaults to 'main'."`
 }
 
 // handleGetReadme handles the get_readme tool call.
 func (m *MCPServer) handleGetReadme(ctx context.Context, req *mcp.CallToolRequest, args GetReadmeArgs) (*mcp.CallToolResult, any, error) {
 	// Validate input
 	if args.RepoRef == "" {
-		return nil, nil, fmt.Errorf("repoRef is required")
+		return toolError(invalidArgument("repoRef is required")), nil, nil
 	}
 
 	// Fetch README content
-	content, err := m.actionsService.GetReadme(args.RepoRef)
+	content, err := m.actionsService.GetReadme(ctx, args.RepoRef)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get README: %w", err)
+		return toolError(fmt.Errorf("failed to get README: %w", err)), nil, nil
+	}
+
+	result := map[string]any{"content": content}
+	if rateLimit := m.rateLimit(); rateLimit != nil {
+		result["rateLimit"] = rateLimit
 	}
 
 	// Return response with README content
@@ -83,5 +156,695 @@ func (m *MCPServer) handleGetReadme(ctx context.Context, req *mcp.CallToolReques
 				Text: content,
 			},
 		},
-	}, map[string]string{"content": content}, nil
+	}, result, nil
+}
+
+// rateLimit returns the GitHub API rate limit most recently reported to the actions service,
+// or nil if none was reported yet, e.g. because all files were served by the raw content host.
+func (m *MCPServer) rateLimit() *github.RateLimit {
+	rateLimit, ok := m.actionsService.RateLimit()
+	if !ok {
+		return nil
+	}
+	return &rateLimit
+}
+
+// formatRateLimit formats the remaining rate limit budget for text output.
+func formatRateLimit(rateLimit *github.RateLimit) string {
+	return fmt.Sprintf("Rate limit: %d/%d requests remaining (resets at %s)",
+		rateLimit.Remaining, rateLimit.Limit, rateLimit.Reset.UTC().Format(time.RFC3339))
+}
+
+// DefaultVersionsLimit is the number of releases returned by list_action_versions if no limit is given.
+const DefaultVersionsLimit = 20
+
+// ListActionVersionsArgs defines the parameters for the list_action_versions tool.
+type ListActionVersionsArgs struct {
+	Repository string `json:"repository" jsonschema:"GitHub repository of the action (e.g., 'actions/setup-node' or 'github/codeql-action')"`
+	Version    string `json:"version,omitempty" jsonschema:"Optional version spec to resolve, e.g. 'latest', '^4', '~4.1', 'v4' or 'v4.2.1'"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"Maximum number of releases to return, newest first (defaults to 20)"`
+}
+
+// ListActionVersionsResult is the structured result of the list_action_versions tool.
+type ListActionVersionsResult struct {
+	github.ActionVersions
+	TotalReleases int                     `json:"totalReleases" jsonschema:"The total number of releases, including those omitted because of the limit"`
+	Resolved      *github.ResolvedVersion `json:"resolved,omitempty" jsonschema:"The release the requested version spec was resolved to"`
+	RateLimit     *github.RateLimit       `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
+}
+
+// handleListActionVersions handles the list_action_versions tool call.
+func (m *MCPServer) handleListActionVersions(ctx context.Context, req *mcp.CallToolRequest, args ListActionVersionsArgs) (*mcp.CallToolResult, any, error) {
+	// Validate input
+	if args.Repository == "" {
+		return toolError(invalidArgument("repository is required")), nil, nil
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = DefaultVersionsLimit
+	}
+
+	versions, err := m.actionsService.ListActionVersions(ctx, args.Repository)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to list action versions: %w", err)), nil, nil
+	}
+
+	var resolved *github.ResolvedVersion
+	if args.Version != "" {
+		var ok bool
+		resolved, ok = versions.Resolve(args.Version)
+		if !ok {
+			return toolError(fmt.Errorf("no version of %s matches '%s': %w", versions.Repository, args.Version, github.ErrNotFound)), nil, nil
+		}
+	}
+
+	result := ListActionVersionsResult{
+		ActionVersions: *versions,
+		TotalReleases:  len(versions.Releases),
+		Resolved:       resolved,
+		RateLimit:      m.rateLimit(),
+	}
+	result.Releases = result.Releases[:min(limit, len(result.Releases))]
+
+	// Format as text output
+	textOutput := fmt.Sprintf("Repository: %s\n", versions.Repository)
+	if versions.Latest != nil {
+		textOutput += fmt.Sprintf("Latest release: %s (%s)\n", versions.Latest.Tag, versions.Latest.SHA)
+	} else {
+		textOutput += "Latest release: none\n"
+	}
+	if resolved != nil {
+		textOutput += fmt.Sprintf("Resolved %s: %s (%s)\n", resolved.Spec, resolved.Tag, resolved.SHA)
+	}
+
+	textOutput += fmt.Sprintf("\nReleases (%d of %d):\n", len(result.Releases), result.TotalReleases)
+	for _, release := range result.Releases {
+		suffix := ""
+		if release.Prerelease {
+			suffix = " (pre-release)"
+		}
+		textOutput += fmt.Sprintf("  %s  %s%s\n", release.Tag, release.SHA, suffix)
+	}
+
+	if len(versions.MovingTags) > 0 {
+		textOutput += "\nMoving tags:\n"
+		for _, tag := range versions.MovingTags {
+			if tag.Release != "" {
+				textOutput += fmt.Sprintf("  %s -> %s  %s\n", tag.Tag, tag.Release, tag.SHA)
+			} else {
+				textOutput += fmt.Sprintf("  %s  %s\n", tag.Tag, tag.SHA)
+			}
+		}
+	}
+
+	if result.RateLimit != nil {
+		textOutput += "\n" + formatRateLimit(result.RateLimit) + "\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// DiffActionVersionsArgs defines the parameters for the diff_action_versions tool.
+type DiffActionVersionsArgs struct {
+	Action      string `json:"action" jsonschema:"GitHub Action without version (e.g., 'docker/build-push-action' or 'github/codeql-action/init')"`
+	FromVersion string `json:"fromVersion" jsonschema:"The version currently in use (e.g., 'v5')"`
+	ToVersion   string `json:"toVersion,omitempty" jsonschema:"The version to update to (e.g., 'v6'). Defaults to 'latest', the newest stable release."`
+}
+
+// DiffActionVersionsResult is the structured result of the diff_action_versions tool.
+type DiffActionVersionsResult struct {
+	github.ActionDiff
+	Breaking  bool              `json:"breaking" jsonschema:"Whether workflows using the old version may fail with the new one, because inputs or outputs were removed or renamed, or inputs became required"`
+	RateLimit *github.RateLimit `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
+}
+
+// handleDiffActionVersions handles the diff_action_versions tool call.
+func (m *MCPServer) handleDiffActionVersions(ctx context.Context, req *mcp.CallToolRequest, args DiffActionVersionsArgs) (*mcp.CallToolResult, any, error) {
+	// Validate input
+	if args.Action == "" {
+		return toolError(invalidArgument("action is required")), nil, nil
+	}
+	if args.FromVersion == "" {
+		return toolError(invalidArgument("fromVersion is required")), nil, nil
+	}
+	toVersion := args.ToVersion
+	if toVersion == "" {
+		toVersion = github.VersionLatest
+	}
+
+	diff, err := m.actionsService.DiffActionVersions(ctx, args.Action, args.FromVersion, toVersion)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to diff action versions: %w", err)), nil, nil
+	}
+
+	result := DiffActionVersionsResult{
+		ActionDiff: *diff,
+		Breaking:   diff.IsBreaking(),
+		RateLimit:  m.rateLimit(),
+	}
+
+	textOutput := fmt.Sprintf("Action: %s\n", args.Action)
+	textOutput += diff.String()
+	if result.RateLimit != nil {
+		textOutput += "\n" + formatRateLimit(result.RateLimit) + "\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// PinActionsArgs defines the parameters for the pin_actions tool.
+type PinActionsArgs struct {
+	Workflow string `json:"workflow" jsonschema:"The content of the workflow YAML file (or composite action.yml) to pin"`
+	Path     string `json:"path,omitempty" jsonschema:"Optional path of the workflow file, used in the diff header (e.g. '.github/workflows/ci.yml')"`
+}
+
+// handlePinActions handles the pin_actions tool call.
+func (m *MCPServer) handlePinActions(ctx context.Context, req *mcp.CallToolRequest, args PinActionsArgs) (*mcp.CallToolResult, any, error) {
+	// Validate input
+	if args.Workflow == "" {
+		return toolError(invalidArgument("workflow is required")), nil, nil
+	}
+	path := args.Path
+	if path == "" {
+		path = "workflow.yml"
+	}
+
+	result, err := m.actionsService.PinWorkflow(ctx, path, []byte(args.Workflow))
+	if err != nil {
+		return toolError(fmt.Errorf("failed to pin actions: %w", err)), nil, nil
+	}
+
+	// Format as text output
+	counts := map[string]int{}
+	textOutput := ""
+	for _, action := range result.Actions {
+		counts[action.Status]++
+		switch action.Status {
+		case github.PinStatusPinned:
+			textOutput += fmt.Sprintf("Line %d: %s -> %s # %s\n", action.Line, action.Original, action.Pinned, action.Version)
+		case github.PinStatusFailed:
+			textOutput += fmt.Sprintf("Line %d: failed to pin %s: %s\n", action.Line, action.Original, action.Reason)
+		}
+	}
+	textOutput = fmt.Sprintf("Pinned: %d, already pinned: %d, skipped: %d, failed: %d\n\n",
+		counts[github.PinStatusPinned], counts[github.PinStatusAlreadyPinned], counts[github.PinStatusSkipped], counts[github.PinStatusFailed]) + textOutput
+
+	if result.Changed() {
+		textOutput += "\n" + result.Diff
+	} else {
+		textOutput += "\nNo changes."
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// InventoryWorkflowActionsArgs defines the parameters for the inventory_workflow_actions tool.
+type InventoryWorkflowActionsArgs struct {
+	Workflow    string `json:"workflow,omitempty" jsonschema:"The content of the workflow YAML file. Either workflow or workflowRef is required."`
+	WorkflowRef string `json:"workflowRef,omitempty" jsonschema:"Reference to a workflow file in a GitHub repository (e.g., 'owner/repo/.github/workflows/ci.yml@main') or in the workspace (e.g., './.github/workflows/ci.yml'). Defaults to the 'main' branch if no ref is given."`
+}
+
+// InventoryWorkflowActionsResult is the structured result of the inventory_workflow_actions tool.
+type InventoryWorkflowActionsResult struct {
+	Name        string                     `json:"name,omitempty" jsonschema:"The name of the workflow"`
+	ResolvedRef *github.ResolvedRef        `json:"resolvedRef,omitempty" jsonschema:"The version and kind of ref the workflowRef was resolved to, if the workflow was fetched from GitHub"`
+	Actions     []workflow.ActionReference `json:"actions" jsonschema:"Every action and reusable workflow referenced with uses:, in order of appearance"`
+	RateLimit   *github.RateLimit          `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
+}
+
+// handleInventoryWorkflowActions handles the inventory_workflow_actions tool call.
+func (m *MCPServer) handleInventoryWorkflowActions(ctx context.Context, req *mcp.CallToolRequest, args InventoryWorkflowActionsArgs) (*mcp.CallToolResult, any, error) {
+	parsed, resolved, err := m.loadWorkflow(ctx, args.Workflow, args.WorkflowRef)
+	if err != nil {
+		return toolError(err), nil, nil
+	}
+
+	result := InventoryWorkflowActionsResult{
+		Name:        parsed.Name,
+		ResolvedRef: resolved,
+		Actions:     parsed.Inventory(),
+	}
+	if resolved != nil {
+		result.RateLimit = m.rateLimit()
+	}
+
+	// Format as text output
+	textOutput := ""
+	if args.WorkflowRef != "" {
+		textOutput += fmt.Sprintf("Workflow: %s\n", args.WorkflowRef)
+		textOutput += fmt.Sprintf("Resolved ref: %s (%s)\n", resolved.Version, resolved.Type)
+	}
+	if parsed.Name != "" {
+		textOutput += fmt.Sprintf("Name: %s\n", parsed.Name)
+	}
+	textOutput += fmt.Sprintf("\nReferences: %d\n", len(result.Actions))
+	for _, action := range result.Actions {
+		textOutput += fmt.Sprintf("  - Line %d: %s (%s, job %s)", action.Line, action.Uses, action.Kind, action.Job)
+		if action.Error != "" {
+			textOutput += fmt.Sprintf(": %s", action.Error)
+		}
+		textOutput += "\n"
+	}
+	if result.RateLimit != nil {
+		textOutput += "\n" + formatRateLimit(result.RateLimit) + "\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// ValidateWorkflowArgs defines the parameters for the validate_workflow tool.
+type ValidateWorkflowArgs struct {
+	Workflow    string `json:"workflow,omitempty" jsonschema:"The content of the workflow YAML file. Either workflow or workflowRef is required."`
+	WorkflowRef string `json:"workflowRef,omitempty" jsonschema:"Reference to a workflow file in a GitHub repository (e.g., 'owner/repo/.github/workflows/ci.yml@main') or in the workspace (e.g., './.github/workflows/ci.yml'). Defaults to the 'main' branch if no ref is given."`
+}
+
+// ValidateWorkflowResult is the structured result of the validate_workflow tool.
+type ValidateWorkflowResult struct {
+	workflow.ValidationResult
+	Valid     bool              `json:"valid" jsonschema:"Whether no errors were found; warnings do not make a workflow invalid"`
+	RateLimit *github.RateLimit `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
+}
+
+// handleValidateWorkflow handles the validate_workflow tool call.
+func (m *MCPServer) handleValidateWorkflow(ctx context.Context, req *mcp.CallToolRequest, args ValidateWorkflowArgs) (*mcp.CallToolResult, any, error) {
+	parsed, _, err := m.loadWorkflow(ctx, args.Workflow, args.WorkflowRef)
+	if err != nil {
+		return toolError(err), nil, nil
+	}
+
+	validation, err := workflow.Validate(ctx, m.actionsService, parsed)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to validate workflow: %w", err)), nil, nil
+	}
+
+	result := ValidateWorkflowResult{
+		ValidationResult: *validation,
+		Valid:            !validation.HasErrors(),
+		RateLimit:        m.rateLimit(),
+	}
+
+	// Format as text output
+	textOutput := fmt.Sprintf("Checked %d actions: %d problems found\n", validation.ActionsChecked, len(validation.Findings))
+	for _, finding := range validation.Findings {
+		textOutput += fmt.Sprintf("  - %s\n", finding.String())
+	}
+	if result.RateLimit != nil {
+		textOutput += "\n" + formatRateLimit(result.RateLimit) + "\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// GetReusableWorkflowParametersArgs defines the parameters for the get_reusable_workflow_parameters tool.
+type GetReusableWorkflowParametersArgs struct {
+	WorkflowRef string `json:"workflowRef" jsonschema:"Reusable workflow reference as used in a job's uses: (e.g., 'octo-org/ci/.github/workflows/build.yml@v2', or './.github/workflows/build.yml' in the workspace). The version can be a tag, branch or commit SHA, or a version spec such as 'latest'."`
+}
+
+// GetReusableWorkflowParametersResult is the structured result of the get_reusable_workflow_parameters tool.
+type GetReusableWorkflowParametersResult struct {
+	Name string `json:"name,omitempty" jsonschema:"The name of the workflow"`
+	workflow.WorkflowCall
+	ResolvedRef *github.ResolvedRef `json:"resolvedRef" jsonschema:"The version and kind of ref (commit, tag or branch) the workflow reference was resolved to"`
+	RateLimit   *github.RateLimit   `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
+}
+
+// handleGetReusableWorkflowParameters handles the get_reusable_workflow_parameters tool call.
+func (m *MCPServer) handleGetReusableWorkflowParameters(ctx context.Context, req *mcp.CallToolRequest, args GetReusableWorkflowParametersArgs) (*mcp.CallToolResult, any, error) {
+	// Validate input
+	if args.WorkflowRef == "" {
+		return toolError(invalidArgument("workflowRef is required")), nil, nil
+	}
+
+	data, resolved, err := m.actionsService.GetWorkflowYAML(ctx, args.WorkflowRef)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to get reusable workflow: %w", err)), nil, nil
+	}
+	parsed, err := workflow.Parse(data)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to get reusable workflow: %w", err)), nil, nil
+	}
+	if parsed.WorkflowCall == nil {
+		return toolError(invalidArgument("%s is not a reusable workflow: it has no workflow_call trigger", args.WorkflowRef)), nil, nil
+	}
+
+	result := GetReusableWorkflowParametersResult{
+		Name:         parsed.Name,
+		WorkflowCall: *parsed.WorkflowCall,
+		ResolvedRef:  resolved,
+		RateLimit:    m.rateLimit(),
+	}
+
+	// Format as text output
+	textOutput := fmt.Sprintf("Workflow: %s\n", args.WorkflowRef)
+	textOutput += fmt.Sprintf("Resolved ref: %s (%s)\n", resolved.Version, resolved.Type)
+	if parsed.Name != "" {
+		textOutput += fmt.Sprintf("Name: %s\n", parsed.Name)
+	}
+	textOutput += fmt.Sprintf("\nInputs: %d defined\n", len(result.Inputs))
+	for _, name := range slices.Sorted(maps.Keys(result.Inputs)) {
+		input := result.Inputs[name]
+		textOutput += fmt.Sprintf("  - %s (%s", name, input.Type)
+		if input.Required {
+			textOutput += ", required"
+		}
+		if input.Default != "" {
+			textOutput += fmt.Sprintf(", default: %s", input.Default)
+		}
+		textOutput += ")\n"
+	}
+	textOutput += fmt.Sprintf("Secrets: %d defined\n", len(result.Secrets))
+	for _, name := range slices.Sorted(maps.Keys(result.Secrets)) {
+		if result.Secrets[name].Required {
+			textOutput += fmt.Sprintf("  - %s (required)\n", name)
+			continue
+		}
+		textOutput += fmt.Sprintf("  - %s\n", name)
+	}
+	textOutput += fmt.Sprintf("Outputs: %d defined\n", len(result.Outputs))
+	for _, name := range slices.Sorted(maps.Keys(result.Outputs)) {
+		textOutput += fmt.Sprintf("  - %s\n", name)
+	}
+	if result.RateLimit != nil {
+		textOutput += "\n" + formatRateLimit(result.RateLimit) + "\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// GetActionDependencyTreeArgs defines the parameters for the get_action_dependency_tree tool.
+type GetActionDependencyTreeArgs struct {
+	ActionRef string `json:"actionRef" jsonschema:"GitHub Action reference (e.g., 'owner/composite-action@v1')"`
+	MaxDepth  int    `json:"maxDepth,omitempty" jsonschema:"Maximum number of levels of nested actions to expand (defaults to 5)"`
+}
+
+// GetActionDependencyTreeResult is the structured result of the get_action_dependency_tree tool.
+// Its output schema is built by dependencyTreeOutputSchema, as JSON schemas cannot be inferred for the recursive tree.
+type GetActionDependencyTreeResult struct {
+	Tree *github.DependencyNode `json:"tree" jsonschema:"The requested action, with the actions it depends on nested below it"`
+	// Actions lists every distinct action in the tree, including the root.
+	Actions   []string          `json:"actions" jsonschema:"Every distinct action in the tree, including the root"`
+	RateLimit *github.RateLimit `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
+}
+
+// handleGetActionDependencyTree handles the get_action_dependency_tree tool call.
+func (m *MCPServer) handleGetActionDependencyTree(ctx context.Context, req *mcp.CallToolRequest, args GetActionDependencyTreeArgs) (*mcp.CallToolResult, any, error) {
+	// Validate input
+	if args.ActionRef == "" {
+		return toolError(invalidArgument("actionRef is required")), nil, nil
+	}
+	if args.MaxDepth < 0 {
+		return toolError(invalidArgument("maxDepth must not be negative")), nil, nil
+	}
+
+	tree, err := m.actionsService.GetActionDependencyTree(ctx, args.ActionRef, &github.DependencyTreeOptions{MaxDepth: args.MaxDepth})
+	if err != nil {
+		return toolError(fmt.Errorf("failed to get action dependency tree: %w", err)), nil, nil
+	}
+
+	result := GetActionDependencyTreeResult{
+		Tree:      tree,
+		Actions:   []string{},
+		RateLimit: m.rateLimit(),
+	}
+
+	// Format as text output
+	textOutput := fmt.Sprintf("Dependency tree of %s:\n", args.ActionRef)
+	seen := map[string]bool{}
+	tree.Walk(func(node *github.DependencyNode, depth int) {
+		if node.Kind == github.DependencyKindAction && !seen[node.Uses] {
+			seen[node.Uses] = true
+			result.Actions = append(result.Actions, node.Uses)
+		}
+		textOutput += fmt.Sprintf("%s- %s%s\n", strings.Repeat("  ", depth), node.Uses, formatDependencyNode(node))
+	})
+	textOutput += fmt.Sprintf("\nDistinct actions: %d\n", len(result.Actions))
+	if result.RateLimit != nil {
+		textOutput += "\n" + formatRateLimit(result.RateLimit) + "\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// formatDependencyNode formats the runtime and status of a dependency tree node as a suffix, e.g. " (node20)".
+func formatDependencyNode(node *github.DependencyNode) string {
+	var details []string
+	switch {
+	case node.Runtime != "":
+		details = append(details, node.Runtime)
+	case node.Kind != github.DependencyKindAction:
+		details = append(details, node.Kind)
+	}
+	if node.Cycle {
+		details = append(details, "cycle, not expanded")
+	}
+	if node.Truncated {
+		details = append(details, "depth limit reached")
+	}
+	if node.Error != "" {
+		details = append(details, "error: "+node.Error)
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(details, ", ") + ")"
+}
+
+// AuditActionArgs defines the parameters for the audit_action tool.
+type AuditActionArgs struct {
+	ActionRef string `json:"actionRef" jsonschema:"GitHub Action reference (e.g., 'actions/setup-node@v3')"`
+}
+
+// AuditActionResult is the structured result of the audit_action tool.
+type AuditActionResult struct {
+	github.ActionAudit
+	Suggestion string            `json:"suggestion,omitempty" jsonschema:"A recommendation how to fix the deprecations, e.g. the newest release which fixes them"`
+	RateLimit  *github.RateLimit `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
+}
+
+// handleAuditAction handles the audit_action tool call.
+func (m *MCPServer) handleAuditAction(ctx context.Context, req *mcp.CallToolRequest, args AuditActionArgs) (*mcp.CallToolResult, any, error) {
+	// Validate input
+	if args.ActionRef == "" {
+		return toolError(invalidArgument("actionRef is required")), nil, nil
+	}
+
+	audit, err := m.actionsService.AuditAction(ctx, args.ActionRef)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to audit action: %w", err)), nil, nil
+	}
+
+	result := AuditActionResult{
+		ActionAudit: *audit,
+		Suggestion:  audit.Suggestion(),
+		RateLimit:   m.rateLimit(),
+	}
+
+	textOutput := audit.String()
+	if result.RateLimit != nil {
+		textOutput += "\n" + formatRateLimit(result.RateLimit) + "\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// GetRepositoryFileArgs defines the parameters for the get_repository_file tool.
+type GetRepositoryFileArgs struct {
+	Repository string `json:"repository" jsonschema:"GitHub repository (e.g., 'actions/checkout')"`
+	Path       string `json:"path" jsonschema:"Path of the file in the repository (e.g., 'dist/index.js', 'CHANGELOG.md' or '.github/workflows/ci.yml')"`
+	Ref        string `json:"ref,omitempty" jsonschema:"Tag, branch or commit SHA, or a version spec such as 'latest' (defaults to 'main')"`
+	MaxBytes   int    `json:"maxBytes,omitempty" jsonschema:"Maximum number of bytes of content to return; longer files are truncated (defaults to 262144)"`
+}
+
+// GetRepositoryFileResult is the structured result of the get_repository_file tool.
+type GetRepositoryFileResult struct {
+	github.RepositoryFile
+	ResolvedRef *github.ResolvedRef `json:"resolvedRef" jsonschema:"The version and kind of ref (commit, tag or branch) the ref was resolved to"`
+	RateLimit   *github.RateLimit   `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
+}
+
+// handleGetRepositoryFile handles the get_repository_file tool call.
+func (m *MCPServer) handleGetRepositoryFile(ctx context.Context, req *mcp.CallToolRequest, args GetRepositoryFileArgs) (*mcp.CallToolResult, any, error) {
+	// Validate input
+	if args.Repository == "" {
+		return toolError(invalidArgument("repository is required")), nil, nil
+	}
+	if args.Path == "" {
+		return toolError(invalidArgument("path is required")), nil, nil
+	}
+	if args.MaxBytes < 0 {
+		return toolError(invalidArgument("maxBytes must not be negative")), nil, nil
+	}
+
+	file, resolved, err := m.actionsService.GetRepositoryFile(ctx, args.Repository, args.Path, args.Ref, args.MaxBytes)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to get repository file: %w", err)), nil, nil
+	}
+
+	result := GetRepositoryFileResult{
+		RepositoryFile: *file,
+		ResolvedRef:    resolved,
+		RateLimit:      m.rateLimit(),
+	}
+
+	// Format as text output: the content itself, or a description of binary files
+	var textOutput string
+	switch {
+	case file.Binary:
+		textOutput = fmt.Sprintf("%s is a binary file (%s, %d bytes); its content is omitted.\n", file.Path, file.ContentType, file.Size)
+	case file.Truncated:
+		textOutput = file.Content + fmt.Sprintf("\n\n[Truncated: showing %d of %d bytes. Request more with maxBytes.]\n", len(file.Content), file.Size)
+	default:
+		textOutput = file.Content
+	}
+	if result.RateLimit != nil {
+		textOutput += "\n" + formatRateLimit(result.RateLimit) + "\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// ListRepositoryDirectoryArgs defines the parameters for the list_repository_directory tool.
+type ListRepositoryDirectoryArgs struct {
+	Repository string `json:"repository" jsonschema:"GitHub repository (e.g., 'actions/checkout')"`
+	Path       string `json:"path,omitempty" jsonschema:"Path of the directory in the repository (e.g., '.github/workflows'); defaults to the root directory"`
+	Ref        string `json:"ref,omitempty" jsonschema:"Tag, branch or commit SHA, or a version spec such as 'latest' (defaults to 'main')"`
+}
+
+// ListRepositoryDirectoryResult is the structured result of the list_repository_directory tool.
+type ListRepositoryDirectoryResult struct {
+	Path        string                  `json:"path" jsonschema:"The listed directory, empty for the root directory"`
+	Entries     []github.DirectoryEntry `json:"entries" jsonschema:"The files and subdirectories of the directory"`
+	ResolvedRef *github.ResolvedRef     `json:"resolvedRef" jsonschema:"The version and kind of ref (commit, tag or branch) the ref was resolved to"`
+	RateLimit   *github.RateLimit       `json:"rateLimit,omitempty" jsonschema:"The GitHub API rate limit budget remaining after the call, if reported by GitHub"`
+}
+
+// handleListRepositoryDirectory handles the list_repository_directory tool call.
+func (m *MCPServer) handleListRepositoryDirectory(ctx context.Context, req *mcp.CallToolRequest, args ListRepositoryDirectoryArgs) (*mcp.CallToolResult, any, error) {
+	// Validate input
+	if args.Repository == "" {
+		return toolError(invalidArgument("repository is required")), nil, nil
+	}
+
+	entries, resolved, err := m.actionsService.ListRepositoryDirectory(ctx, args.Repository, args.Path, args.Ref)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to list repository directory: %w", err)), nil, nil
+	}
+
+	result := ListRepositoryDirectoryResult{
+		Path:        strings.Trim(args.Path, "/"),
+		Entries:     entries,
+		ResolvedRef: resolved,
+		RateLimit:   m.rateLimit(),
+	}
+
+	// Format as text output
+	textOutput := fmt.Sprintf("Contents of %s/%s at %s (%s):\n", args.Repository, result.Path, resolved.Version, resolved.Type)
+	for _, entry := range entries {
+		switch entry.Type {
+		case github.EntryTypeDir:
+			textOutput += fmt.Sprintf("  %s/\n", entry.Name)
+		case github.EntryTypeFile:
+			textOutput += fmt.Sprintf("  %s (%d bytes)\n", entry.Name, entry.Size)
+		default:
+			textOutput += fmt.Sprintf("  %s (%s)\n", entry.Name, entry.Type)
+		}
+	}
+	if result.RateLimit != nil {
+		textOutput += "\n" + formatRateLimit(result.RateLimit) + "\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: textOutput,
+			},
+		},
+	}, result, nil
+}
+
+// loadWorkflow parses a workflow given either inline as content or as a reference to a file on GitHub.
+// The returned ResolvedRef is nil for inline workflows.
+func (m *MCPServer) loadWorkflow(ctx context.Context, content, workflowRef string) (*workflow.Workflow, *github.ResolvedRef, error) {
+	content, resolved, err := m.fetchWorkflow(ctx, content, workflowRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := workflow.Parse([]byte(content))
+	if err != nil {
+		return nil, nil, invalidArgument("%v", err)
+	}
+	return parsed, resolved, nil
+}
+
+// fetchWorkflow returns the content of a workflow given either inline as content or as a reference
+// to a file on GitHub or in the workspace. The returned ResolvedRef is nil for inline workflows.
+func (m *MCPServer) fetchWorkflow(ctx context.Context, content, workflowRef string) (string, *github.ResolvedRef, error) {
+	switch {
+	case content != "" && workflowRef != "":
+		return "", nil, invalidArgument("only one of workflow and workflowRef may be given")
+	case workflowRef != "":
+		data, resolved, err := m.actionsService.GetFile(ctx, workflowRef)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to fetch workflow: %w", err)
+		}
+		return string(data), resolved, nil
+	case content == "":
+		return "", nil, invalidArgument("either workflow or workflowRef is required")
+	}
+	return content, nil, nil
 }
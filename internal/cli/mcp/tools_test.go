@@ -0,0 +1,452 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+	"github.com/techprimate/github-actions-utils-cli/internal/workflow"
+)
+
+// validateAgainstOutputSchema marshals value to JSON and validates it against the output schema inferred for T.
+func validateAgainstOutputSchema[T any](t *testing.T, value any) error {
+	t.Helper()
+	return validateAgainstSchema(t, outputSchemaFor[T](), value)
+}
+
+// validateAgainstSchema marshals value to JSON and validates it against schema.
+func validateAgainstSchema(t *testing.T, schema *jsonschema.Schema, value any) error {
+	t.Helper()
+
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		t.Fatalf("Failed to resolve output schema: %v", err)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Failed to marshal value: %v", err)
+	}
+	var instance map[string]any
+	if err := json.Unmarshal(data, &instance); err != nil {
+		t.Fatalf("Failed to unmarshal value: %v", err)
+	}
+
+	return resolved.Validate(instance)
+}
+
+func TestGetActionParametersResult_MatchesOutputSchema(t *testing.T) {
+	result := GetActionParametersResult{
+		ActionMetadata: github.ActionMetadata{
+			Name:        "Checkout",
+			Description: "Checkout a Git repository",
+			Inputs: map[string]github.ActionInput{
+				"fetch-depth": {Description: "Number of commits to fetch", Default: "1"},
+			},
+			Outputs: map[string]github.ActionOutput{
+				"ref": {Description: "The checked out ref"},
+			},
+			Runs: github.ActionRuns{Using: "node20", Main: "dist/index.js"},
+		},
+		ResolvedRef: &github.ResolvedRef{Version: "v5", Type: github.RefTypeTag},
+		RateLimit:   &github.RateLimit{Limit: 5000, Remaining: 4999, Reset: time.Unix(1735732800, 0), Resource: "core"},
+	}
+
+	if err := validateAgainstOutputSchema[GetActionParametersResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestGetActionParametersResult_DockerActionMatchesOutputSchema(t *testing.T) {
+	result := GetActionParametersResult{
+		ActionMetadata: github.ActionMetadata{
+			Name:        "Lint",
+			Description: "Lint files",
+			Runs:        github.ActionRuns{Using: "docker", Image: "Dockerfile", Args: []string{"--strict"}},
+		},
+		ResolvedRef: &github.ResolvedRef{Version: "v2", Type: github.RefTypeTag},
+		Docker: &github.DockerAction{
+			Dockerfile: &github.Dockerfile{
+				Path:       "Dockerfile",
+				BaseImage:  "python:3.12-slim",
+				Stages:     []github.DockerfileStage{{Image: "python:3.12-slim"}},
+				Entrypoint: `["/entrypoint.sh"]`,
+			},
+		},
+	}
+
+	if err := validateAgainstOutputSchema[GetActionParametersResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestHandleGetActionParameters_DockerfileUnavailable(t *testing.T) {
+	workspace := t.TempDir()
+	actionDir := filepath.Join(workspace, ".github", "actions", "lint")
+	if err := os.MkdirAll(actionDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(actionDir, "action.yml"), []byte("name: Lint\nruns:\n  using: docker\n  image: Dockerfile\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	server := NewMCPServer(github.NewActionsService(&github.ActionsServiceOptions{Workspace: workspace}), nil)
+
+	result, structured, err := server.handleGetActionParameters(context.Background(), &mcp.CallToolRequest{}, GetActionParametersArgs{
+		ActionRef: "./.github/actions/lint",
+	})
+	if err != nil {
+		t.Fatalf("handleGetActionParameters() unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handleGetActionParameters() = %+v, want the metadata without Docker details", result)
+	}
+	got := structured.(GetActionParametersResult)
+	if got.Name != "Lint" || got.Docker != nil || got.DockerError == "" {
+		t.Errorf("handleGetActionParameters() = %+v, want the metadata and why the Dockerfile is unavailable", got)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "Docker details unavailable") {
+		t.Errorf("handleGetActionParameters() text = %q, want it to report the Docker details as unavailable", text)
+	}
+}
+
+func TestFormatDockerAction(t *testing.T) {
+	runs := &github.ActionRuns{Using: "docker", Image: "Dockerfile", Args: []string{"--strict", "."}, Env: map[string]string{"MODE": "ci"}}
+	docker := &github.DockerAction{Dockerfile: &github.Dockerfile{Path: "Dockerfile", BaseImage: "python:3.12-slim", Entrypoint: `["/entrypoint.sh"]`}}
+
+	want := "Dockerfile: Dockerfile\nBase image: python:3.12-slim\nEntrypoint: [\"/entrypoint.sh\"]\nArgs: --strict .\nEnv: MODE=ci\n"
+	if got := formatDockerAction(runs, docker); got != want {
+		t.Errorf("formatDockerAction() = %q, want %q", got, want)
+	}
+}
+
+func TestGetActionParametersResult_OutputSchemaProperties(t *testing.T) {
+	schema := outputSchemaFor[GetActionParametersResult]()
+
+	for _, property := range []string{"name", "description", "inputs", "outputs", "runs", "resolvedRef"} {
+		if _, ok := schema.Properties[property]; !ok {
+			t.Errorf("Output schema is missing property %q", property)
+		}
+	}
+}
+
+func TestFormatRateLimit(t *testing.T) {
+	rateLimit := &github.RateLimit{Limit: 5000, Remaining: 4321, Reset: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	want := "Rate limit: 4321/5000 requests remaining (resets at 2025-01-01T12:00:00Z)"
+	if got := formatRateLimit(rateLimit); got != want {
+		t.Errorf("formatRateLimit() = %q, want %q", got, want)
+	}
+}
+
+func TestListActionVersionsResult_MatchesOutputSchema(t *testing.T) {
+	result := ListActionVersionsResult{
+		ActionVersions: github.ActionVersions{
+			Repository: "actions/checkout",
+			Latest:     &github.ActionVersion{Tag: "v5.0.0", SHA: "08c6903cd8c0fde910a37f88322edcfb5dd907a8"},
+			Releases: []github.ActionVersion{
+				{Tag: "v5.0.0", SHA: "08c6903cd8c0fde910a37f88322edcfb5dd907a8"},
+			},
+			MovingTags: []github.ActionVersion{
+				{Tag: "v5", SHA: "08c6903cd8c0fde910a37f88322edcfb5dd907a8", Release: "v5.0.0"},
+			},
+		},
+		TotalReleases: 1,
+		Resolved:      &github.ResolvedVersion{Spec: "latest", Tag: "v5.0.0", SHA: "08c6903cd8c0fde910a37f88322edcfb5dd907a8"},
+	}
+
+	if err := validateAgainstOutputSchema[ListActionVersionsResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestDiffActionVersionsResult_MatchesOutputSchema(t *testing.T) {
+	from := &github.ActionMetadata{
+		Inputs: map[string]github.ActionInput{"tag": {Description: "Tag"}},
+		Runs:   github.ActionRuns{Using: "node16"},
+	}
+	to := &github.ActionMetadata{
+		Inputs: map[string]github.ActionInput{"tags": {Description: "Tag"}, "sbom": {Required: true}},
+		Runs:   github.ActionRuns{Using: "node20"},
+	}
+	diff := github.DiffActionMetadata(from, to)
+	diff.From = &github.ResolvedRef{Version: "v5", Type: github.RefTypeTag}
+	diff.To = &github.ResolvedRef{Version: "v6.0.0", Type: github.RefTypeTag, SHA: "08c6903cd8c0fde910a37f88322edcfb5dd907a8"}
+
+	result := DiffActionVersionsResult{ActionDiff: *diff, Breaking: diff.IsBreaking()}
+	if err := validateAgainstOutputSchema[DiffActionVersionsResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestPinResult_MatchesOutputSchema(t *testing.T) {
+	result := github.PinResult{
+		Content: "steps:\n  - uses: actions/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8 # v5.0.0\n",
+		Diff:    "--- a/workflow.yml\n+++ b/workflow.yml\n",
+		Actions: []github.PinnedAction{
+			{Line: 2, Original: "actions/checkout@v5", Pinned: "actions/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8", Version: "v5.0.0", Status: github.PinStatusPinned},
+			{Line: 3, Original: "./local", Status: github.PinStatusSkipped, Reason: "local action"},
+		},
+	}
+
+	if err := validateAgainstOutputSchema[github.PinResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestHandleInventoryWorkflowActions(t *testing.T) {
+	server := NewMCPServer(github.NewActionsService(nil), nil)
+
+	result, data, err := server.handleInventoryWorkflowActions(context.Background(), &mcp.CallToolRequest{}, InventoryWorkflowActionsArgs{
+		Workflow: "name: CI\non: push\njobs:\n  build:\n    steps:\n      - uses: actions/checkout@v5\n      - uses: ./.github/actions/setup\n",
+	})
+	if err != nil {
+		t.Fatalf("handleInventoryWorkflowActions() unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handleInventoryWorkflowActions() returned error result: %+v", result)
+	}
+
+	inventory, ok := data.(InventoryWorkflowActionsResult)
+	if !ok {
+		t.Fatalf("handleInventoryWorkflowActions() data = %T, want InventoryWorkflowActionsResult", data)
+	}
+	if inventory.Name != "CI" || len(inventory.Actions) != 2 {
+		t.Errorf("handleInventoryWorkflowActions() = %+v, want workflow CI with 2 references", inventory)
+	}
+	if err := validateAgainstOutputSchema[InventoryWorkflowActionsResult](t, inventory); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestHandleInventoryWorkflowActions_InvalidArguments(t *testing.T) {
+	server := NewMCPServer(github.NewActionsService(nil), nil)
+
+	tests := []struct {
+		name string
+		args InventoryWorkflowActionsArgs
+	}{
+		{name: "no workflow", args: InventoryWorkflowActionsArgs{}},
+		{name: "both workflow and ref", args: InventoryWorkflowActionsArgs{Workflow: "on: push", WorkflowRef: "org/repo/.github/workflows/ci.yml@main"}},
+		{name: "invalid workflow", args: InventoryWorkflowActionsArgs{Workflow: "jobs: ["}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := server.handleInventoryWorkflowActions(context.Background(), &mcp.CallToolRequest{}, tt.args)
+			if err != nil {
+				t.Fatalf("handleInventoryWorkflowActions() unexpected error: %v", err)
+			}
+			if !result.IsError || result.Meta[ErrorCodeKey] != ErrorCodeInvalidArgument {
+				t.Errorf("handleInventoryWorkflowActions() = %+v, want an error result with code %q", result, ErrorCodeInvalidArgument)
+			}
+		})
+	}
+}
+
+func TestInventoryWorkflowActionsResult_MatchesOutputSchema(t *testing.T) {
+	result := InventoryWorkflowActionsResult{
+		Name:        "CI",
+		ResolvedRef: &github.ResolvedRef{Version: "main", Type: github.RefTypeBranch},
+		Actions: []workflow.ActionReference{
+			{Uses: "actions/checkout@v5", Kind: workflow.KindAction, Job: "build", Step: "Checkout", Line: 6, Column: 15, Ref: &github.Ref{Owner: "actions", Repo: "checkout", Version: "v5"}},
+			{Uses: "docker://alpine:3.20", Kind: workflow.KindDocker, Job: "build", Line: 7, Column: 15},
+		},
+	}
+
+	if err := validateAgainstOutputSchema[InventoryWorkflowActionsResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestValidateWorkflowResult_MatchesOutputSchema(t *testing.T) {
+	result := ValidateWorkflowResult{
+		ValidationResult: workflow.ValidationResult{
+			ActionsChecked: 1,
+			Findings: []workflow.Finding{
+				{
+					Kind:       workflow.FindingUnknownInput,
+					Severity:   workflow.SeverityError,
+					Message:    "actions/setup-node@v4 has no input 'node_version' (did you mean 'node-version'?)",
+					Job:        "build",
+					Step:       "actions/setup-node@v4",
+					Uses:       "actions/setup-node@v4",
+					Input:      "node_version",
+					Suggestion: "node-version",
+					Line:       9,
+					Column:     11,
+				},
+			},
+		},
+		Valid: false,
+	}
+
+	if err := validateAgainstOutputSchema[ValidateWorkflowResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestGetReusableWorkflowParametersResult_MatchesOutputSchema(t *testing.T) {
+	result := GetReusableWorkflowParametersResult{
+		Name: "Build",
+		WorkflowCall: workflow.WorkflowCall{
+			Inputs:  map[string]workflow.WorkflowCallInput{"environment": {Type: "string", Required: true}},
+			Secrets: map[string]workflow.WorkflowCallSecret{"token": {Required: true}},
+			Outputs: map[string]workflow.WorkflowCallOutput{"url": {Value: "${{ jobs.deploy.outputs.url }}"}},
+		},
+		ResolvedRef: &github.ResolvedRef{Version: "v2", Type: github.RefTypeTag},
+	}
+
+	if err := validateAgainstOutputSchema[GetReusableWorkflowParametersResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestHandleGetReusableWorkflowParameters_InvalidRef(t *testing.T) {
+	server := NewMCPServer(github.NewActionsService(nil), nil)
+
+	result, _, err := server.handleGetReusableWorkflowParameters(context.Background(), &mcp.CallToolRequest{}, GetReusableWorkflowParametersArgs{
+		WorkflowRef: "actions/checkout@v5",
+	})
+	if err != nil {
+		t.Fatalf("handleGetReusableWorkflowParameters() unexpected error: %v", err)
+	}
+	if !result.IsError || result.Meta[ErrorCodeKey] != ErrorCodeInvalidRef {
+		t.Errorf("handleGetReusableWorkflowParameters() = %+v, want an error result with code %q", result, ErrorCodeInvalidRef)
+	}
+}
+
+func TestGetActionDependencyTreeResult_MatchesOutputSchema(t *testing.T) {
+	result := GetActionDependencyTreeResult{
+		Tree: &github.DependencyNode{
+			Uses:        "example/composite@v1",
+			Kind:        github.DependencyKindAction,
+			Runtime:     "composite",
+			ResolvedRef: &github.ResolvedRef{Version: "v1", Type: github.RefTypeTag},
+			Dependencies: []*github.DependencyNode{
+				{
+					Uses:         "example/nested@v2",
+					Kind:         github.DependencyKindAction,
+					Runtime:      "composite",
+					Dependencies: []*github.DependencyNode{{Uses: "example/composite@v1", Kind: github.DependencyKindAction, Cycle: true}},
+				},
+				{Uses: "./.github/actions/setup", Kind: github.DependencyKindLocal},
+				{Uses: "example/missing@v1", Kind: github.DependencyKindAction, Error: "not found"},
+			},
+		},
+		Actions: []string{"example/composite@v1", "example/nested@v2", "example/missing@v1"},
+	}
+
+	schema := dependencyTreeOutputSchema()
+	if err := validateAgainstSchema(t, schema, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+
+	// Nested nodes are validated against the node definition as well
+	invalid := map[string]any{
+		"tree": map[string]any{
+			"uses":         "example/composite@v1",
+			"kind":         github.DependencyKindAction,
+			"dependencies": []any{map[string]any{"uses": "example/nested@v2", "kind": github.DependencyKindAction, "unknown": true}},
+		},
+		"actions": []string{},
+	}
+	if err := validateAgainstSchema(t, schema, invalid); err == nil {
+		t.Error("Nested node with an unknown property matches output schema, want an error")
+	}
+}
+
+func TestFormatDependencyNode(t *testing.T) {
+	tests := []struct {
+		name string
+		node *github.DependencyNode
+		want string
+	}{
+		{name: "runtime", node: &github.DependencyNode{Kind: github.DependencyKindAction, Runtime: "node20"}, want: " (node20)"},
+		{name: "local", node: &github.DependencyNode{Kind: github.DependencyKindLocal}, want: " (local)"},
+		{name: "cycle", node: &github.DependencyNode{Kind: github.DependencyKindAction, Cycle: true}, want: " (cycle, not expanded)"},
+		{name: "truncated", node: &github.DependencyNode{Kind: github.DependencyKindAction, Runtime: "composite", Truncated: true}, want: " (composite, depth limit reached)"},
+		{name: "error", node: &github.DependencyNode{Kind: github.DependencyKindAction, Error: "not found"}, want: " (error: not found)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDependencyNode(tt.node); got != tt.want {
+				t.Errorf("formatDependencyNode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditActionResult_MatchesOutputSchema(t *testing.T) {
+	metadata := &github.ActionMetadata{
+		Inputs: map[string]github.ActionInput{"always-auth": {DeprecationMessage: "No longer needed"}},
+		Runs:   github.ActionRuns{Using: "node16", Main: "dist/index.js"},
+	}
+	audit := github.ActionAudit{
+		Action:         "actions/setup-node@v3",
+		ResolvedRef:    &github.ResolvedRef{Version: "v3", Type: github.RefTypeTag},
+		Runtime:        metadata.Runs.Using,
+		Findings:       github.AuditActionMetadata(metadata),
+		Latest:         &github.ResolvedVersion{Spec: "latest", Tag: "v5.0.0", SHA: "a0853c24544627f65ddf259abe73b1d18a591444"},
+		LatestFindings: []github.AuditFinding{},
+	}
+
+	result := AuditActionResult{ActionAudit: audit, Suggestion: audit.Suggestion()}
+	if err := validateAgainstOutputSchema[AuditActionResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestGetRepositoryFileResult_MatchesOutputSchema(t *testing.T) {
+	result := GetRepositoryFileResult{
+		RepositoryFile: github.RepositoryFile{Path: "dist/logo.png", Size: 1024, ContentType: "image/png", Binary: true},
+		ResolvedRef:    &github.ResolvedRef{Version: "v5", Type: github.RefTypeTag},
+	}
+	if err := validateAgainstOutputSchema[GetRepositoryFileResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestListRepositoryDirectoryResult_MatchesOutputSchema(t *testing.T) {
+	result := ListRepositoryDirectoryResult{
+		Entries:     []github.DirectoryEntry{{Name: "action.yml", Path: "action.yml", Type: github.EntryTypeFile, Size: 120}},
+		ResolvedRef: &github.ResolvedRef{Version: "main", Type: github.RefTypeBranch},
+	}
+	if err := validateAgainstOutputSchema[ListRepositoryDirectoryResult](t, result); err != nil {
+		t.Errorf("Result does not match output schema: %v", err)
+	}
+}
+
+func TestHandleGetRepositoryFile_InvalidArguments(t *testing.T) {
+	server := NewMCPServer(github.NewActionsService(nil), nil)
+
+	tests := []struct {
+		name     string
+		args     GetRepositoryFileArgs
+		wantCode string
+	}{
+		{name: "missing repository", args: GetRepositoryFileArgs{Path: "README.md"}, wantCode: ErrorCodeInvalidArgument},
+		{name: "missing path", args: GetRepositoryFileArgs{Repository: "actions/checkout"}, wantCode: ErrorCodeInvalidArgument},
+		{name: "negative maxBytes", args: GetRepositoryFileArgs{Repository: "actions/checkout", Path: "README.md", MaxBytes: -1}, wantCode: ErrorCodeInvalidArgument},
+		{name: "path outside of the repository", args: GetRepositoryFileArgs{Repository: "actions/checkout", Path: "../README.md"}, wantCode: ErrorCodeInvalidRef},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := server.handleGetRepositoryFile(context.Background(), &mcp.CallToolRequest{}, tt.args)
+			if err != nil {
+				t.Fatalf("handleGetRepositoryFile() unexpected error: %v", err)
+			}
+			if !result.IsError || result.Meta[ErrorCodeKey] != tt.wantCode {
+				t.Errorf("handleGetRepositoryFile() = %+v, want an error result with code %q", result, tt.wantCode)
+			}
+		})
+	}
+}
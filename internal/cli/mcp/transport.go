@@ -0,0 +1,37 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// transportKey is the context key of the transport a request was received over.
+type transportKey struct{}
+
+// transportMiddleware records the transport the server is served over in the context of
+// every request, so that it can be reported by WithSentryTracing.
+func transportMiddleware(transport string) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(context.WithValue(ctx, transportKey{}, transport), method, req)
+		}
+	}
+}
+
+// transportFromContext returns the transport a request was received over,
+// defaulting to TransportStdio.
+func transportFromContext(ctx context.Context) string {
+	if transport, ok := ctx.Value(transportKey{}).(string); ok {
+		return transport
+	}
+	return TransportStdio
+}
+
+// networkTransport returns the network transport underlying an MCP transport.
+func networkTransport(transport string) string {
+	if transport == TransportStdio {
+		return NetworkTransportPipe
+	}
+	return NetworkTransportTCP
+}
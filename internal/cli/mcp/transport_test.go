@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestTransportMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		transport     string
+		wantTransport string
+		wantNetwork   string
+	}{
+		{name: "default", wantTransport: TransportStdio, wantNetwork: NetworkTransportPipe},
+		{name: "stdio", transport: TransportStdio, wantTransport: TransportStdio, wantNetwork: NetworkTransportPipe},
+		{name: "streamable HTTP", transport: TransportHTTP, wantTransport: TransportHTTP, wantNetwork: NetworkTransportTCP},
+		{name: "SSE", transport: TransportSSE, wantTransport: TransportSSE, wantNetwork: NetworkTransportTCP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := mcp.MethodHandler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				got = transportFromContext(ctx)
+				return nil, nil
+			})
+			if tt.transport != "" {
+				handler = transportMiddleware(tt.transport)(handler)
+			}
+			if _, err := handler(context.Background(), "tools/call", nil); err != nil {
+				t.Fatalf("handler() unexpected error: %v", err)
+			}
+
+			if got != tt.wantTransport {
+				t.Errorf("transportFromContext() = %q, want %q", got, tt.wantTransport)
+			}
+			if network := networkTransport(got); network != tt.wantNetwork {
+				t.Errorf("networkTransport(%q) = %q, want %q", got, network, tt.wantNetwork)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -11,90 +14,254 @@ import (
 // ActionsService provides functionality to fetch and parse GitHub Actions.
 type ActionsService struct {
 	httpClient *http.Client
+	endpoints  *Endpoints
+	token      string
+	cache      *Cache
+	timeout    time.Duration
+	workspace  string
+	rateLimit  rateLimitState
+}
+
+// Default timeouts used if ActionsServiceOptions does not specify them.
+const (
+	DefaultRequestTimeout = 30 * time.Second
+	DefaultTimeout        = 2 * time.Minute
+)
+
+// ActionsServiceOptions configures an ActionsService.
+type ActionsServiceOptions struct {
+	// Token is used to authenticate every request sent to GitHub.
+	// If empty, requests are sent anonymously and only public repositories are accessible.
+	// See DiscoverToken for looking up a token from the environment.
+	Token string
+
+	// Endpoints are the base URLs of the GitHub instance to talk to.
+	// If nil, github.com is used. See DiscoverEndpoints for GitHub Enterprise Server.
+	Endpoints *Endpoints
+
+	// Cache stores fetched files on disk to avoid downloading them again.
+	// If nil, every file is fetched from GitHub.
+	Cache *Cache
+
+	// RequestTimeout limits the duration of a single HTTP request, including reading the response body.
+	// Each retry of a failed request gets a fresh timeout. If zero, DefaultRequestTimeout is used.
+	RequestTimeout time.Duration
+
+	// Timeout limits the overall duration of an operation such as GetActionParameters,
+	// which may send multiple requests while resolving a ref. If zero, DefaultTimeout is used.
+	Timeout time.Duration
+
+	// MaxRetries is the number of times a request failing with a transient error,
+	// such as a server error or an exceeded rate limit, is retried.
+	// If zero, DefaultMaxRetries is used. If negative, requests are never retried.
+	MaxRetries int
+
+	// MaxRetryWait is the longest time to wait before retrying a request, e.g. until a rate limit resets.
+	// Requests which would need to wait longer fail immediately. If zero, DefaultMaxRetryWait is used.
+	MaxRetryWait time.Duration
+
+	// Workspace is the directory local references such as "./.github/actions/setup" are resolved
+	// against, usually the root of a checked-out repository. It can be overridden per operation
+	// with WithWorkspace. If empty, local references cannot be resolved.
+	Workspace string
 }
 
 // NewActionsService creates a new ActionsService.
-func NewActionsService() *ActionsService {
+// If opts is nil, the service is created with default options.
+func NewActionsService(opts *ActionsServiceOptions) *ActionsService {
+	if opts == nil {
+		opts = &ActionsServiceOptions{}
+	}
+	endpoints := opts.Endpoints
+	if endpoints == nil {
+		endpoints = DefaultEndpoints()
+	}
+	requestTimeout := opts.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = DefaultRequestTimeout
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	maxRetries := opts.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	maxRetryWait := opts.MaxRetryWait
+	if maxRetryWait <= 0 {
+		maxRetryWait = DefaultMaxRetryWait
+	}
 	return &ActionsService{
-		httpClient: &http.Client{},
+		httpClient: &http.Client{
+			// The request timeout applies to each attempt, so that retries are not cut short
+			Transport: newRetryTransport(&tracingTransport{base: http.DefaultTransport}, maxRetries, maxRetryWait, requestTimeout),
+		},
+		endpoints: endpoints,
+		token:     opts.Token,
+		cache:     opts.Cache,
+		timeout:   timeout,
+		workspace: opts.Workspace,
 	}
 }
 
-// ParseActionRef parses an action reference string like "owner/repo@version".
+// ParseActionRef parses an action reference string like "owner/repo@version" or "owner/repo/path@version".
 // The version part is required for actions.
 // Examples:
 //   - "actions/checkout@v5" -> {Owner: "actions", Repo: "checkout", Version: "v5"}
 //   - "actions/setup-node@v4" -> {Owner: "actions", Repo: "setup-node", Version: "v4"}
+//   - "github/codeql-action/init@v3" -> {Owner: "github", Repo: "codeql-action", Path: "init", Version: "v3"}
 func ParseActionRef(ref string) (*Ref, error) {
 	return ParseRef(ref, true, "")
 }
 
-// FetchActionYAML fetches the action.yml or action.yaml file from GitHub's raw content CDN.
+// FetchActionYAML fetches the action.yml or action.yaml file from GitHub (see FetchRawFile).
 // It tries both common action file names in order of preference.
-// It constructs the URL using tags format:
-// https://raw.githubusercontent.com/{owner}/{repo}/refs/tags/{version}/action.yml
-func (s *ActionsService) FetchActionYAML(owner, repo, version string) ([]byte, error) {
+// For actions living in a subdirectory (ref.Path), the files are looked up in that directory.
+// The version is resolved as a commit SHA, tag or branch (see refCandidates),
+// and the returned ResolvedRef reports which kind of ref was matched.
+func (s *ActionsService) FetchActionYAML(ctx context.Context, ref *Ref) ([]byte, *ResolvedRef, error) {
 	// Try common action filenames in order of preference
-	actionFilenames := []string{"action.yml", "action.yaml"}
-	urlPath := fmt.Sprintf("refs/tags/%s", version)
+	actionFilenames := []string{ref.FilePath("action.yml"), ref.FilePath("action.yaml")}
 
+	data, resolved, err := s.fetchFileAtVersion(ctx, ref.Owner, ref.Repo, ref.Version, refCandidates(ref.Version), actionFilenames)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil, fmt.Errorf("action.yml or action.yaml not found for %s: %w", ref, err)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch action.yml for %s: %w", ref, err)
+	}
+	return data, resolved, nil
+}
+
+// fetchFileAtVersion fetches the first of the given filenames that exists at the given version.
+// Each ref candidate (see refCandidates) is tried in order, and for each candidate all filenames
+// are tried, so that the preferred kind of ref always takes precedence over the others.
+// Only files that were not found lead to trying the next one; any other failure, such as
+// an exceeded rate limit or a missing permission, is returned immediately, as it would
+// affect every remaining candidate as well.
+func (s *ActionsService) fetchFileAtVersion(ctx context.Context, owner, repo, version string, candidates []refCandidate, filenames []string) ([]byte, *ResolvedRef, error) {
 	var lastErr error
-	for _, filename := range actionFilenames {
-		data, err := s.FetchRawFile(owner, repo, urlPath, filename)
-		if err == nil {
-			return data, nil
+	for _, candidate := range candidates {
+		for _, filename := range filenames {
+			// Stop trying further candidates once the operation was cancelled or timed out
+			if err := ctx.Err(); err != nil {
+				return nil, nil, err
+			}
+			data, err := s.FetchRawFile(ctx, owner, repo, candidate.URLPath, filename)
+			if err == nil {
+				return data, &ResolvedRef{Version: version, Type: candidate.Type}, nil
+			}
+			if !errors.Is(err, ErrNotFound) {
+				return nil, nil, err
+			}
+			lastErr = err
 		}
-		lastErr = err
 	}
 
-	// If we get here, none of the action files were found
+	// If we get here, none of the files were found for any of the candidates
 	if lastErr != nil {
-		return nil, fmt.Errorf("action.yml or action.yaml not found for %s/%s@%s: %w", owner, repo, version, lastErr)
+		return nil, nil, lastErr
 	}
-	return nil, fmt.Errorf("action.yml or action.yaml not found for %s/%s@%s", owner, repo, version)
+	return nil, nil, fmt.Errorf("no files to fetch")
 }
 
-// ParseActionYAML parses YAML data into a map that can be JSON-encoded.
-// This converts the action.yml structure into a JSON-compatible format.
-func ParseActionYAML(data []byte) (map[string]interface{}, error) {
-	var result map[string]interface{}
+// ParseActionYAML parses action.yml data into a typed ActionMetadata.
+// The result can be JSON-encoded and keeps the key names used in action.yml.
+func ParseActionYAML(data []byte) (*ActionMetadata, error) {
+	var result ActionMetadata
 
 	if err := yaml.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
-	return result, nil
+	return &result, nil
 }
 
 // GetActionParameters fetches and parses a GitHub Action's action.yml file.
-// It takes an action reference (e.g., "actions/checkout@v5") and returns
-// the parsed action.yml content as a JSON-compatible map.
-func (s *ActionsService) GetActionParameters(actionRef string) (map[string]interface{}, error) {
-	// Parse the action reference
-	ref, err := ParseActionRef(actionRef)
-	if err != nil {
-		return nil, fmt.Errorf("invalid action reference: %w", err)
+// It takes an action reference (e.g., "actions/checkout@v5" or "github/codeql-action/init@v3") and returns
+// the parsed action.yml metadata, together with the kind of ref
+// (commit, tag or branch) the version was resolved to.
+// Version specs such as "latest" or "^4" are first resolved to the matching release (see ResolveActionVersion).
+//
+// Docker images referenced as "docker://image:tag" have no action.yml; they are described
+// as a Docker action without inputs and outputs running the image.
+// Local actions referenced as "./path" are read from the workspace (see FetchLocalActionYAML).
+func (s *ActionsService) GetActionParameters(ctx context.Context, actionRef string) (*ActionMetadata, *ResolvedRef, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if IsDockerRef(actionRef) {
+		image, err := ParseDockerImage(actionRef)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid action reference: %w", err)
+		}
+		metadata := &ActionMetadata{
+			Name:        image.String(),
+			Description: fmt.Sprintf("Runs the Docker image %s", image),
+			Runs:        ActionRuns{Using: RunsUsingDocker, Image: DockerRefPrefix + image.String()},
+		}
+		return metadata, &ResolvedRef{Version: image.Version(), Type: RefTypeImage}, nil
 	}
 
-	// Fetch the action.yml file
-	yamlData, err := s.FetchActionYAML(ref.Owner, ref.Repo, ref.Version)
+	yamlData, resolved, err := s.GetActionYAML(ctx, actionRef)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Parse YAML to map
+	// Parse YAML into typed metadata
 	parsed, err := ParseActionYAML(yamlData)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return parsed, nil
+	return parsed, resolved, nil
+}
+
+// GetActionYAML fetches the unparsed action.yml file of a GitHub Action, e.g. "actions/checkout@v5"
+// or "github/codeql-action/init@v3", together with the kind of ref the version was resolved to.
+// Version specs such as "latest" or "^4" are first resolved to the matching release (see ResolveActionVersion).
+// Local actions referenced as "./path" are read from the workspace (see FetchLocalActionYAML).
+func (s *ActionsService) GetActionYAML(ctx context.Context, actionRef string) ([]byte, *ResolvedRef, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if IsDockerRef(actionRef) {
+		return nil, nil, invalidRef("Docker image '%s' has no action.yml", actionRef)
+	}
+	if IsLocalRef(actionRef) {
+		return s.FetchLocalActionYAML(ctx, actionRef)
+	}
+
+	// Parse the action reference
+	ref, err := ParseActionRef(actionRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid action reference: %w", err)
+	}
+
+	// Resolve version specs to a release tag
+	var sha string
+	if IsVersionSpec(ref.Version) {
+		version, err := s.resolveVersion(ctx, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		ref.Version, sha = version.Tag, version.SHA
+	}
+
+	// Fetch the action.yml file
+	yamlData, resolved, err := s.FetchActionYAML(ctx, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved.SHA = sha
+	return yamlData, resolved, nil
 }
 
 // GetActionParametersJSON is a convenience method that returns the action
-// parameters as a JSON string instead of a map.
-func (s *ActionsService) GetActionParametersJSON(actionRef string) (string, error) {
-	params, err := s.GetActionParameters(actionRef)
+// parameters as a JSON string instead of a struct.
+func (s *ActionsService) GetActionParametersJSON(ctx context.Context, actionRef string) (string, error) {
+	params, _, err := s.GetActionParameters(ctx, actionRef)
 	if err != nil {
 		return "", err
 	}
@@ -110,42 +277,90 @@ func (s *ActionsService) GetActionParametersJSON(actionRef string) (string, erro
 // ParseRepoRef parses a repository reference string like "owner/repo@ref".
 // The ref can be a tag, branch name, or commit SHA.
 // If no ref is provided (e.g., "owner/repo"), it defaults to "main".
+// An optional subdirectory path may follow the repository name.
 // Examples:
 //   - "actions/checkout@v5" -> {Owner: "actions", Repo: "checkout", Version: "v5"}
 //   - "owner/repo@main" -> {Owner: "owner", Repo: "repo", Version: "main"}
 //   - "owner/repo" -> {Owner: "owner", Repo: "repo", Version: "main"}
+//   - "owner/repo/docs@main" -> {Owner: "owner", Repo: "repo", Path: "docs", Version: "main"}
 func ParseRepoRef(ref string) (*Ref, error) {
 	return ParseRef(ref, false, "main")
 }
 
-// FetchReadme fetches the README.md file from GitHub's raw content CDN.
+// FetchReadme fetches the README.md file from GitHub (see FetchRawFile).
 // It tries multiple common README filenames in order of preference.
-// The ref can be a branch name, tag, or commit SHA.
-func (s *ActionsService) FetchReadme(owner, repo, ref string) (string, error) {
+// If ref.Path is set, the README is looked up in that subdirectory.
+// The version can be a branch name, tag, or commit SHA; unlike for other files,
+// a branch takes precedence over a tag with the same name.
+func (s *ActionsService) FetchReadme(ctx context.Context, ref *Ref) (string, error) {
 	// Try common README filenames in order of preference
 	readmeNames := []string{"README.md", "readme.md", "Readme.md", "README", "readme"}
-	urlPath := fmt.Sprintf("refs/heads/%s", ref)
+	for i, name := range readmeNames {
+		readmeNames[i] = ref.FilePath(name)
+	}
 
-	var lastErr error
-	for _, filename := range readmeNames {
-		data, err := s.FetchRawFile(owner, repo, urlPath, filename)
-		if err == nil {
-			return string(data), nil
+	data, _, err := s.fetchFileAtVersion(ctx, ref.Owner, ref.Repo, ref.Version, branchFirstRefCandidates(ref.Version), readmeNames)
+	if errors.Is(err, ErrNotFound) {
+		return "", fmt.Errorf("README not found in repository %s: %w", ref, err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch README for %s: %w", ref, err)
+	}
+	return string(data), nil
+}
+
+// FetchFile fetches the file at ref.Path from GitHub (see FetchRawFile).
+// The version is resolved as a commit SHA, tag or branch (see refCandidates),
+// and the returned ResolvedRef reports which kind of ref was matched.
+func (s *ActionsService) FetchFile(ctx context.Context, ref *Ref) ([]byte, *ResolvedRef, error) {
+	if ref.Path == "" {
+		return nil, nil, invalidRef("reference '%s' does not point to a file", ref)
+	}
+
+	data, resolved, err := s.fetchFileAtVersion(ctx, ref.Owner, ref.Repo, ref.Version, refCandidates(ref.Version), []string{ref.Path})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+	return data, resolved, nil
+}
+
+// GetFile fetches a file from a GitHub repository.
+// It takes a file reference (e.g., "owner/repo/.github/workflows/ci.yml@v2") and returns
+// the file content together with the kind of ref the version was resolved to.
+// If no ref is provided, it defaults to "main".
+// Local files referenced as "./path" are read from the workspace (see ReadLocalFile).
+func (s *ActionsService) GetFile(ctx context.Context, fileRef string) ([]byte, *ResolvedRef, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if IsLocalRef(fileRef) {
+		localPath, err := ParseLocalRef(fileRef)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid file reference: %w", err)
+		}
+		data, err := s.ReadLocalFile(ctx, localPath)
+		if err != nil {
+			return nil, nil, err
 		}
-		lastErr = err
+		return data, &ResolvedRef{Version: localPath, Type: RefTypeLocal}, nil
 	}
 
-	// If we get here, none of the README files were found
-	if lastErr != nil {
-		return "", fmt.Errorf("README not found in repository %s/%s@%s: %w", owner, repo, ref, lastErr)
+	// Parse the file reference
+	ref, err := ParseRepoRef(fileRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid file reference: %w", err)
 	}
-	return "", fmt.Errorf("README not found in repository %s/%s@%s", owner, repo, ref)
+
+	return s.FetchFile(ctx, ref)
 }
 
 // GetReadme fetches a README.md file from a GitHub repository.
 // It takes a repository reference (e.g., "owner/repo@main" or "owner/repo") and returns
 // the README content as a string. If no ref is provided, it defaults to "main".
-func (s *ActionsService) GetReadme(repoRef string) (string, error) {
+func (s *ActionsService) GetReadme(ctx context.Context, repoRef string) (string, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
 	// Parse the repository reference
 	ref, err := ParseRepoRef(repoRef)
 	if err != nil {
@@ -153,7 +368,7 @@ func (s *ActionsService) GetReadme(repoRef string) (string, error) {
 	}
 
 	// Fetch the README file
-	content, err := s.FetchReadme(ref.Owner, ref.Repo, ref.Version)
+	content, err := s.FetchReadme(ctx, ref)
 	if err != nil {
 		return "", err
 	}
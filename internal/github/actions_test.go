@@ -1,6 +1,12 @@
 package github
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -10,6 +16,7 @@ func TestParseActionRef(t *testing.T) {
 		input       string
 		wantOwner   string
 		wantRepo    string
+		wantPath    string
 		wantVersion string
 		wantErr     bool
 	}{
@@ -74,8 +81,31 @@ func TestParseActionRef(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "invalid format - too many slashes",
-			input:   "actions/github/checkout@v5",
+			name:        "action in subdirectory",
+			input:       "github/codeql-action/init@v3",
+			wantOwner:   "github",
+			wantRepo:    "codeql-action",
+			wantPath:    "init",
+			wantVersion: "v3",
+			wantErr:     false,
+		},
+		{
+			name:        "action in nested subdirectory",
+			input:       "aws-actions/amazon-ecr-login/actions/login@v2",
+			wantOwner:   "aws-actions",
+			wantRepo:    "amazon-ecr-login",
+			wantPath:    "actions/login",
+			wantVersion: "v2",
+			wantErr:     false,
+		},
+		{
+			name:    "invalid format - empty path segment",
+			input:   "github/codeql-action//init@v3",
+			wantErr: true,
+		},
+		{
+			name:    "Docker image",
+			input:   "docker://alpine:3.20",
 			wantErr: true,
 		},
 	}
@@ -102,6 +132,9 @@ func TestParseActionRef(t *testing.T) {
 			if got.Repo != tt.wantRepo {
 				t.Errorf("ParseActionRef() Repo = %v, want %v", got.Repo, tt.wantRepo)
 			}
+			if got.Path != tt.wantPath {
+				t.Errorf("ParseActionRef() Path = %v, want %v", got.Path, tt.wantPath)
+			}
 			if got.Version != tt.wantVersion {
 				t.Errorf("ParseActionRef() Version = %v, want %v", got.Version, tt.wantVersion)
 			}
@@ -115,6 +148,7 @@ func TestParseRepoRef(t *testing.T) {
 		input       string
 		wantOwner   string
 		wantRepo    string
+		wantPath    string
 		wantVersion string
 		wantErr     bool
 	}{
@@ -198,9 +232,13 @@ func TestParseRepoRef(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "invalid format - too many slashes",
-			input:   "owner/group/repo@main",
-			wantErr: true,
+			name:        "repo reference with subdirectory path",
+			input:       "owner/group/repo@main",
+			wantOwner:   "owner",
+			wantRepo:    "group",
+			wantPath:    "repo",
+			wantVersion: "main",
+			wantErr:     false,
 		},
 		{
 			name:    "invalid format - multiple @ symbols",
@@ -231,9 +269,163 @@ func TestParseRepoRef(t *testing.T) {
 			if got.Repo != tt.wantRepo {
 				t.Errorf("ParseRepoRef() Repo = %v, want %v", got.Repo, tt.wantRepo)
 			}
+			if got.Path != tt.wantPath {
+				t.Errorf("ParseRepoRef() Path = %v, want %v", got.Path, tt.wantPath)
+			}
 			if got.Version != tt.wantVersion {
 				t.Errorf("ParseRepoRef() Version = %v, want %v", got.Version, tt.wantVersion)
 			}
 		})
 	}
 }
+
+// newTestService creates an ActionsService whose raw content host and REST API are served by handler.
+// If useContentsAPI is true, no raw content host is configured, as on GitHub Enterprise Server.
+func newTestService(t *testing.T, handler http.Handler, useContentsAPI bool) *ActionsService {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	endpoints := &Endpoints{
+		WebURL: server.URL,
+		APIURL: server.URL + "/api/v3",
+		RawURL: server.URL + "/raw",
+	}
+	if useContentsAPI {
+		endpoints.RawURL = ""
+	}
+	return NewActionsService(&ActionsServiceOptions{
+		Token:     "test-token",
+		Endpoints: endpoints,
+	})
+}
+
+func TestFetchReadme_PrefersBranchOverTag(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/raw/example/docs/refs/heads/stable/README.md":
+			fmt.Fprint(w, "branch README")
+		case "/raw/example/docs/refs/tags/stable/README.md", "/raw/example/docs/refs/tags/v1/README.md":
+			fmt.Fprint(w, "tag README")
+		default:
+			http.NotFound(w, r)
+		}
+	}), false)
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "stable", want: "branch README"},
+		{version: "v1", want: "tag README"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := service.FetchReadme(context.Background(), &Ref{Owner: "example", Repo: "docs", Version: tt.version})
+			if err != nil {
+				t.Fatalf("FetchReadme() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FetchReadme() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetActionParameters_ResolvesBranch(t *testing.T) {
+	var requested []string
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization header = %q, want %q", got, "Bearer test-token")
+		}
+		if r.URL.Path == "/raw/actions/checkout/refs/heads/main/action.yml" {
+			fmt.Fprint(w, "name: Checkout\nruns:\n  using: node20\n  main: index.js\n")
+			return
+		}
+		http.NotFound(w, r)
+	}), false)
+
+	metadata, resolved, err := service.GetActionParameters(context.Background(), "actions/checkout@main")
+	if err != nil {
+		t.Fatalf("GetActionParameters() unexpected error: %v", err)
+	}
+	if metadata.Name != "Checkout" {
+		t.Errorf("GetActionParameters() Name = %q, want %q", metadata.Name, "Checkout")
+	}
+	if resolved.Type != RefTypeBranch {
+		t.Errorf("GetActionParameters() resolved type = %q, want %q", resolved.Type, RefTypeBranch)
+	}
+
+	// Tags are tried first, with both file names, before falling back to branches
+	want := []string{
+		"/raw/actions/checkout/refs/tags/main/action.yml",
+		"/raw/actions/checkout/refs/tags/main/action.yaml",
+		"/raw/actions/checkout/refs/heads/main/action.yml",
+	}
+	if strings.Join(requested, ",") != strings.Join(want, ",") {
+		t.Errorf("Requested paths = %v, want %v", requested, want)
+	}
+}
+
+func TestGetActionParameters_SubdirectoryWithContentsAPI(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/repos/github/codeql-action/contents/init/action.yml" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("ref"); got != "refs/tags/v3" {
+			t.Errorf("ref query parameter = %q, want %q", got, "refs/tags/v3")
+		}
+		if got := r.Header.Get("Accept"); got != "application/vnd.github.raw" {
+			t.Errorf("Accept header = %q, want %q", got, "application/vnd.github.raw")
+		}
+		fmt.Fprint(w, "name: 'CodeQL: Init'\nruns:\n  using: node20\n  main: init.js\n")
+	}), true)
+
+	metadata, resolved, err := service.GetActionParameters(context.Background(), "github/codeql-action/init@v3")
+	if err != nil {
+		t.Fatalf("GetActionParameters() unexpected error: %v", err)
+	}
+	if metadata.Name != "CodeQL: Init" {
+		t.Errorf("GetActionParameters() Name = %q, want %q", metadata.Name, "CodeQL: Init")
+	}
+	if resolved.Type != RefTypeTag {
+		t.Errorf("GetActionParameters() resolved type = %q, want %q", resolved.Type, RefTypeTag)
+	}
+}
+
+func TestGetActionParameters_NotFound(t *testing.T) {
+	service := newTestService(t, http.NotFoundHandler(), false)
+
+	if _, _, err := service.GetActionParameters(context.Background(), "actions/missing@v1"); err == nil {
+		t.Errorf("GetActionParameters() expected error but got none")
+	}
+}
+
+func TestGetFile(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/raw/org/workflows/refs/heads/main/.github/workflows/ci.yml" {
+			fmt.Fprint(w, "on: push\n")
+			return
+		}
+		http.NotFound(w, r)
+	}), false)
+
+	data, resolved, err := service.GetFile(context.Background(), "org/workflows/.github/workflows/ci.yml")
+	if err != nil {
+		t.Fatalf("GetFile() unexpected error: %v", err)
+	}
+	if string(data) != "on: push\n" {
+		t.Errorf("GetFile() = %q, want %q", data, "on: push\n")
+	}
+	if resolved.Version != "main" || resolved.Type != RefTypeBranch {
+		t.Errorf("GetFile() resolved = %+v, want branch main", resolved)
+	}
+
+	if _, _, err := service.GetFile(context.Background(), "org/workflows@main"); !errors.Is(err, ErrInvalidRef) {
+		t.Errorf("GetFile() error = %v, want ErrInvalidRef for a reference without path", err)
+	}
+}
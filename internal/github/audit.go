@@ -0,0 +1,220 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Kinds of AuditFinding.
+const (
+	// AuditDeprecatedRuntime is an action running on a deprecated Node.js runtime.
+	AuditDeprecatedRuntime = "deprecated_runtime"
+	// AuditDeprecatedInput is an input the action marks as deprecated.
+	AuditDeprecatedInput = "deprecated_input"
+	// AuditDeprecatedCommand is a composite step using a deprecated workflow command such as set-output.
+	AuditDeprecatedCommand = "deprecated_command"
+)
+
+// Severities of an AuditFinding.
+const (
+	AuditSeverityError   = "error"
+	AuditSeverityWarning = "warning"
+)
+
+// deprecatedRuntime describes why a runtime is deprecated.
+type deprecatedRuntime struct {
+	severity string
+	message  string
+}
+
+// deprecatedRuntimes are the values of runs.using which GitHub deprecated.
+// See: https://github.blog/changelog/label/actions/
+var deprecatedRuntimes = map[string]deprecatedRuntime{
+	"node12": {AuditSeverityError, "node12 is no longer supported; GitHub runs the action on a newer Node.js version, which may break it"},
+	"node16": {AuditSeverityError, "node16 is no longer supported; GitHub runs the action on a newer Node.js version, which may break it"},
+	"node20": {AuditSeverityWarning, "node20 is deprecated, as Node.js 20 reached its end of life in April 2026; actions should move to node24"},
+}
+
+// deprecatedCommands are workflow commands written to stdout which were replaced by environment files.
+// See: https://github.blog/changelog/2022-10-11-github-actions-deprecating-save-state-and-set-output-commands/
+var deprecatedCommands = map[string]string{
+	"set-output": "write to $GITHUB_OUTPUT instead",
+	"save-state": "write to $GITHUB_STATE instead",
+	"set-env":    "write to $GITHUB_ENV instead; the command is disabled",
+	"add-path":   "write to $GITHUB_PATH instead; the command is disabled",
+}
+
+// deprecatedCommandPattern matches deprecated workflow commands such as "::set-output name=x::y".
+var deprecatedCommandPattern = regexp.MustCompile(`::(set-output|save-state|set-env|add-path)\b`)
+
+// AuditFinding is a single deprecation found in an action.
+type AuditFinding struct {
+	Kind     string `json:"kind" jsonschema:"One of deprecated_runtime, deprecated_input or deprecated_command"`
+	Severity string `json:"severity" jsonschema:"One of error or warning"`
+	Message  string `json:"message" jsonschema:"A human-readable description of the deprecation"`
+	Input    string `json:"input,omitempty" jsonschema:"The deprecated input"`
+	Step     string `json:"step,omitempty" jsonschema:"The name, ID or index of the composite step using a deprecated command"`
+	Command  string `json:"command,omitempty" jsonschema:"The deprecated workflow command, e.g. set-output"`
+}
+
+// ActionAudit is the result of auditing an action for deprecations.
+type ActionAudit struct {
+	Action      string         `json:"action" jsonschema:"The audited action reference"`
+	ResolvedRef *ResolvedRef   `json:"resolvedRef" jsonschema:"The version and kind of ref the action reference was resolved to"`
+	Runtime     string         `json:"runtime" jsonschema:"The runtime of the action (runs.using)"`
+	Findings    []AuditFinding `json:"findings" jsonschema:"The deprecations found in the audited version"`
+	// Latest is the newest stable release. It is only looked up if the audited version has findings.
+	Latest *ResolvedVersion `json:"latest,omitempty" jsonschema:"The newest stable release, looked up if the audited version has findings"`
+	// LatestFindings are the findings remaining in the newest stable release.
+	LatestFindings []AuditFinding `json:"latestFindings,omitempty" jsonschema:"The deprecations remaining in the newest stable release"`
+}
+
+// Suggestion returns a recommendation how to fix the findings, or an empty string if there are none.
+func (a *ActionAudit) Suggestion() string {
+	switch {
+	case len(a.Findings) == 0:
+		return ""
+	case a.Latest == nil:
+		return "No stable release of the action was found to compare with."
+	case a.ResolvedRef != nil && a.ResolvedRef.Version == a.Latest.Tag:
+		return "The audited version is the newest stable release; the deprecations are not fixed yet."
+	case len(a.LatestFindings) == 0:
+		return fmt.Sprintf("Update to %s (commit %s), which fixes all deprecations.", a.Latest.Tag, a.Latest.SHA)
+	case len(a.LatestFindings) < len(a.Findings):
+		return fmt.Sprintf("Update to %s (commit %s), which fixes %d of %d deprecations.", a.Latest.Tag, a.Latest.SHA, len(a.Findings)-len(a.LatestFindings), len(a.Findings))
+	default:
+		return fmt.Sprintf("The newest stable release %s has the same deprecations.", a.Latest.Tag)
+	}
+}
+
+// String formats the audit as a human-readable report.
+func (a *ActionAudit) String() string {
+	var b strings.Builder
+	version := ""
+	if a.ResolvedRef != nil {
+		version = fmt.Sprintf(" (%s %s)", a.ResolvedRef.Type, a.ResolvedRef.Version)
+	}
+	fmt.Fprintf(&b, "Audit of %s%s, runs using %s:\n", a.Action, version, a.Runtime)
+	if len(a.Findings) == 0 {
+		b.WriteString("No deprecations found.\n")
+		return b.String()
+	}
+
+	b.WriteString("\n")
+	for _, finding := range a.Findings {
+		fmt.Fprintf(&b, "  - %s: %s\n", finding.Severity, finding.Message)
+	}
+	fmt.Fprintf(&b, "\n%s\n", a.Suggestion())
+	return b.String()
+}
+
+// AuditAction fetches an action (see GetActionParameters) and reports deprecated runtimes
+// (e.g. node16), inputs the action marks as deprecated, and composite steps using deprecated
+// workflow commands such as "::set-output". If any deprecation is found, the newest stable
+// release is looked up and audited as well, to suggest a version that fixes them.
+func (s *ActionsService) AuditAction(ctx context.Context, actionRef string) (*ActionAudit, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	metadata, resolved, err := s.GetActionParameters(ctx, actionRef)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := &ActionAudit{
+		Action:      actionRef,
+		ResolvedRef: resolved,
+		Runtime:     metadata.Runs.Using,
+		Findings:    AuditActionMetadata(metadata),
+	}
+	if len(audit.Findings) == 0 || IsDockerRef(actionRef) || IsLocalRef(actionRef) {
+		return audit, nil
+	}
+
+	// Look for the newest release, which may fix the deprecations
+	ref, err := ParseActionRef(actionRef)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action reference: %w", err)
+	}
+	ref.Version = VersionLatest
+	latest, err := s.resolveVersion(ctx, ref)
+	if errors.Is(err, ErrNotFound) {
+		return audit, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	audit.Latest = latest
+
+	if latest.Tag == resolved.Version {
+		audit.LatestFindings = audit.Findings
+		return audit, nil
+	}
+	ref.Version = latest.Tag
+	latestMetadata, _, err := s.GetActionParameters(ctx, ref.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to audit latest release %s: %w", latest.Tag, err)
+	}
+	audit.LatestFindings = AuditActionMetadata(latestMetadata)
+	return audit, nil
+}
+
+// AuditActionMetadata reports the deprecations found in parsed action metadata (see AuditAction).
+func AuditActionMetadata(metadata *ActionMetadata) []AuditFinding {
+	findings := []AuditFinding{}
+
+	if runtime, ok := deprecatedRuntimes[metadata.Runs.Using]; ok {
+		findings = append(findings, AuditFinding{
+			Kind:     AuditDeprecatedRuntime,
+			Severity: runtime.severity,
+			Message:  runtime.message,
+		})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(metadata.Inputs)) {
+		if message := metadata.Inputs[name].DeprecationMessage; message != "" {
+			findings = append(findings, AuditFinding{
+				Kind:     AuditDeprecatedInput,
+				Severity: AuditSeverityWarning,
+				Message:  fmt.Sprintf("input '%s' is deprecated: %s", name, message),
+				Input:    name,
+			})
+		}
+	}
+
+	for i, step := range metadata.Runs.Steps {
+		seen := map[string]bool{}
+		for _, match := range deprecatedCommandPattern.FindAllStringSubmatch(step.Run, -1) {
+			command := match[1]
+			if seen[command] {
+				continue
+			}
+			seen[command] = true
+			findings = append(findings, AuditFinding{
+				Kind:     AuditDeprecatedCommand,
+				Severity: AuditSeverityError,
+				Message:  fmt.Sprintf("step '%s' uses the deprecated ::%s command; %s", compositeStepLabel(step, i), command, deprecatedCommands[command]),
+				Step:     compositeStepLabel(step, i),
+				Command:  command,
+			})
+		}
+	}
+	return findings
+}
+
+// compositeStepLabel returns a human-readable label for a composite step: its name, its ID, or its 1-based index.
+func compositeStepLabel(step CompositeStep, index int) string {
+	switch {
+	case strings.TrimSpace(step.Name) != "":
+		return step.Name
+	case step.ID != "":
+		return step.ID
+	default:
+		return fmt.Sprintf("#%d", index+1)
+	}
+}
@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAuditActionMetadata(t *testing.T) {
+	metadata := &ActionMetadata{
+		Inputs: map[string]ActionInput{
+			"token":       {Description: "GitHub token"},
+			"always-auth": {DeprecationMessage: "No longer needed"},
+		},
+		Runs: ActionRuns{
+			Using: "node16",
+			Steps: []CompositeStep{
+				{Name: "Compute", Run: `echo "::set-output name=a::1"` + "\n" + `echo "::set-output name=b::2"`},
+				{Run: `echo "::save-state name=pid::$PID"`},
+				{Run: `echo "result=1" >> "$GITHUB_OUTPUT"`},
+			},
+		},
+	}
+
+	got := AuditActionMetadata(metadata)
+	want := []struct {
+		kind    string
+		subject string
+	}{
+		{kind: AuditDeprecatedRuntime},
+		{kind: AuditDeprecatedInput, subject: "always-auth"},
+		{kind: AuditDeprecatedCommand, subject: "Compute"},
+		{kind: AuditDeprecatedCommand, subject: "#2"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("AuditActionMetadata() = %+v, want %d findings", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].Kind != w.kind || got[i].Input+got[i].Step != w.subject {
+			t.Errorf("Findings[%d] = %+v, want kind %s for %q", i, got[i], w.kind, w.subject)
+		}
+	}
+	if got[0].Severity != AuditSeverityError || !strings.Contains(got[3].Message, "GITHUB_STATE") {
+		t.Errorf("AuditActionMetadata() = %+v, want node16 to be an error and save-state to suggest GITHUB_STATE", got)
+	}
+}
+
+func TestAuditActionMetadata_NoFindings(t *testing.T) {
+	metadata := &ActionMetadata{Runs: ActionRuns{Using: "node24", Main: "index.js"}}
+
+	if got := AuditActionMetadata(metadata); len(got) != 0 {
+		t.Errorf("AuditActionMetadata() = %+v, want no findings", got)
+	}
+}
+
+func TestAuditAction_SuggestsLatestRelease(t *testing.T) {
+	tags := []Tag{{Name: "v4.0.0", SHA: "sha-4"}, {Name: "v3.1.0", SHA: "sha-3"}}
+	service := newTestService(t, tagsHandler(t, tags, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/raw/actions/example/refs/tags/v3.1.0/action.yml":
+			fmt.Fprint(w, "name: Example\ninputs:\n  old:\n    deprecationMessage: Use new\nruns:\n  using: node16\n  main: index.js\n")
+		case "/raw/actions/example/refs/tags/v4.0.0/action.yml":
+			fmt.Fprint(w, "name: Example\ninputs:\n  old:\n    deprecationMessage: Use new\nruns:\n  using: node24\n  main: index.js\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}), false)
+
+	audit, err := service.AuditAction(context.Background(), "actions/example@v3.1.0")
+	if err != nil {
+		t.Fatalf("AuditAction() unexpected error: %v", err)
+	}
+	if audit.Runtime != "node16" || len(audit.Findings) != 2 {
+		t.Errorf("AuditAction() = %+v, want node16 with 2 findings", audit)
+	}
+	if audit.Latest == nil || audit.Latest.Tag != "v4.0.0" || len(audit.LatestFindings) != 1 {
+		t.Fatalf("AuditAction() latest = %+v with findings %+v, want v4.0.0 with 1 finding", audit.Latest, audit.LatestFindings)
+	}
+	if want := "Update to v4.0.0 (commit sha-4), which fixes 1 of 2 deprecations."; audit.Suggestion() != want {
+		t.Errorf("Suggestion() = %q, want %q", audit.Suggestion(), want)
+	}
+}
+
+func TestActionAudit_Suggestion(t *testing.T) {
+	finding := AuditFinding{Kind: AuditDeprecatedRuntime}
+	latest := &ResolvedVersion{Spec: "latest", Tag: "v5.0.0", SHA: "abc"}
+
+	tests := []struct {
+		name  string
+		audit ActionAudit
+		want  string
+	}{
+		{name: "no findings", audit: ActionAudit{}, want: ""},
+		{name: "no releases", audit: ActionAudit{Findings: []AuditFinding{finding}}, want: "No stable release"},
+		{name: "fixed", audit: ActionAudit{Findings: []AuditFinding{finding}, Latest: latest}, want: "which fixes all deprecations"},
+		{name: "not fixed", audit: ActionAudit{Findings: []AuditFinding{finding}, Latest: latest, LatestFindings: []AuditFinding{finding}}, want: "has the same deprecations"},
+		{
+			name:  "audited latest",
+			audit: ActionAudit{Findings: []AuditFinding{finding}, ResolvedRef: &ResolvedRef{Version: "v5.0.0"}, Latest: latest, LatestFindings: []AuditFinding{finding}},
+			want:  "is the newest stable release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.audit.Suggestion()
+			if (tt.want == "" && got != "") || !strings.Contains(got, tt.want) {
+				t.Errorf("Suggestion() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActionAudit_String(t *testing.T) {
+	audit := &ActionAudit{
+		Action:      "actions/example@v3",
+		ResolvedRef: &ResolvedRef{Version: "v3", Type: RefTypeTag},
+		Runtime:     "node16",
+		Findings:    []AuditFinding{{Kind: AuditDeprecatedRuntime, Severity: AuditSeverityError, Message: "node16 is no longer supported"}},
+	}
+
+	want := "Audit of actions/example@v3 (tag v3), runs using node16:\n\n" +
+		"  - error: node16 is no longer supported\n\n" +
+		"No stable release of the action was found to compare with.\n"
+	if got := audit.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultHost is the host name of github.com, used to look up credentials stored by the gh CLI.
+const DefaultHost = "github.com"
+
+// Token sources reported by DiscoverToken.
+const (
+	TokenSourceNone                  = ""
+	TokenSourceFlag                  = "flag"
+	TokenSourceGitHubToken           = "GITHUB_TOKEN"
+	TokenSourceGHToken               = "GH_TOKEN"
+	TokenSourceGHEnterpriseToken     = "GH_ENTERPRISE_TOKEN"
+	TokenSourceGitHubEnterpriseToken = "GITHUB_ENTERPRISE_TOKEN"
+	TokenSourceGHCLI                 = "gh"
+)
+
+// DiscoverToken looks up a GitHub token for the given host and reports where it was found.
+// Sources are checked in order of precedence:
+//  1. flagValue, e.g. the value of a --github-token flag
+//  2. For hosts other than github.com, the GH_ENTERPRISE_TOKEN and GITHUB_ENTERPRISE_TOKEN environment variables
+//  3. The GITHUB_TOKEN environment variable
+//  4. The GH_TOKEN environment variable
+//  5. The oauth_token stored for host in the gh CLI's hosts.yml
+//
+// Like the gh CLI, the enterprise variables take precedence for other hosts, as GITHUB_TOKEN is
+// usually a github.com token, e.g. on GitHub Actions runners.
+//
+// If no token is found, an empty token and TokenSourceNone are returned and
+// requests are sent anonymously.
+func DiscoverToken(flagValue, host string) (token, source string) {
+	if flagValue != "" {
+		return flagValue, TokenSourceFlag
+	}
+	if host != DefaultHost {
+		if value := os.Getenv("GH_ENTERPRISE_TOKEN"); value != "" {
+			return value, TokenSourceGHEnterpriseToken
+		}
+		if value := os.Getenv("GITHUB_ENTERPRISE_TOKEN"); value != "" {
+			return value, TokenSourceGitHubEnterpriseToken
+		}
+	}
+	if value := os.Getenv("GITHUB_TOKEN"); value != "" {
+		return value, TokenSourceGitHubToken
+	}
+	if value := os.Getenv("GH_TOKEN"); value != "" {
+		return value, TokenSourceGHToken
+	}
+	if value := readGHCLIToken(ghConfigDir(), host); value != "" {
+		return value, TokenSourceGHCLI
+	}
+	return "", TokenSourceNone
+}
+
+// ghConfigDir returns the configuration directory used by the gh CLI.
+// It follows the same lookup order as gh itself: GH_CONFIG_DIR, XDG_CONFIG_HOME,
+// AppData on Windows and finally ~/.config.
+func ghConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI")
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh")
+}
+
+// readGHCLIToken reads the token stored for host from the gh CLI's hosts.yml in configDir.
+// Recent versions of gh store tokens in the system keyring instead, in which case
+// no token is found in the file and an empty string is returned.
+func readGHCLIToken(configDir, host string) string {
+	if configDir == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "hosts.yml"))
+	if err != nil {
+		return ""
+	}
+
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return ""
+	}
+
+	return hosts[host].OAuthToken
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeHostsFile(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write hosts.yml: %v", err)
+	}
+	return dir
+}
+
+func TestDiscoverToken(t *testing.T) {
+	hostsDir := writeHostsFile(t, `
+github.com:
+    user: octocat
+    oauth_token: gho_from_gh_cli
+    git_protocol: https
+`)
+
+	tests := []struct {
+		name            string
+		host            string
+		flagValue       string
+		githubToken     string
+		ghToken         string
+		enterpriseToken string
+		ghConfigDir     string
+		wantToken       string
+		wantSource      string
+	}{
+		{
+			name:        "flag takes precedence over everything",
+			flagValue:   "from_flag",
+			githubToken: "from_github_token",
+			ghToken:     "from_gh_token",
+			ghConfigDir: hostsDir,
+			wantToken:   "from_flag",
+			wantSource:  TokenSourceFlag,
+		},
+		{
+			name:        "GITHUB_TOKEN takes precedence over GH_TOKEN",
+			githubToken: "from_github_token",
+			ghToken:     "from_gh_token",
+			ghConfigDir: hostsDir,
+			wantToken:   "from_github_token",
+			wantSource:  TokenSourceGitHubToken,
+		},
+		{
+			name:        "GH_TOKEN takes precedence over gh CLI",
+			ghToken:     "from_gh_token",
+			ghConfigDir: hostsDir,
+			wantToken:   "from_gh_token",
+			wantSource:  TokenSourceGHToken,
+		},
+		{
+			name:            "enterprise token takes precedence over GITHUB_TOKEN for other hosts",
+			host:            "ghe.example.com",
+			githubToken:     "from_github_token",
+			ghToken:         "from_gh_token",
+			enterpriseToken: "from_enterprise_token",
+			ghConfigDir:     hostsDir,
+			wantToken:       "from_enterprise_token",
+			wantSource:      TokenSourceGHEnterpriseToken,
+		},
+		{
+			name:            "enterprise token is ignored for github.com",
+			githubToken:     "from_github_token",
+			enterpriseToken: "from_enterprise_token",
+			ghConfigDir:     hostsDir,
+			wantToken:       "from_github_token",
+			wantSource:      TokenSourceGitHubToken,
+		},
+		{
+			name:        "GITHUB_TOKEN is used for other hosts without enterprise token",
+			host:        "ghe.example.com",
+			githubToken: "from_github_token",
+			ghConfigDir: hostsDir,
+			wantToken:   "from_github_token",
+			wantSource:  TokenSourceGitHubToken,
+		},
+		{
+			name:        "falls back to gh CLI hosts file",
+			ghConfigDir: hostsDir,
+			wantToken:   "gho_from_gh_cli",
+			wantSource:  TokenSourceGHCLI,
+		},
+		{
+			name:        "no token available",
+			ghConfigDir: t.TempDir(),
+			wantToken:   "",
+			wantSource:  TokenSourceNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_TOKEN", tt.githubToken)
+			t.Setenv("GH_TOKEN", tt.ghToken)
+			t.Setenv("GH_ENTERPRISE_TOKEN", tt.enterpriseToken)
+			t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+			t.Setenv("GH_CONFIG_DIR", tt.ghConfigDir)
+
+			host := tt.host
+			if host == "" {
+				host = DefaultHost
+			}
+			token, source := DiscoverToken(tt.flagValue, host)
+			if token != tt.wantToken {
+				t.Errorf("DiscoverToken() token = %q, want %q", token, tt.wantToken)
+			}
+			if source != tt.wantSource {
+				t.Errorf("DiscoverToken() source = %q, want %q", source, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestReadGHCLIToken(t *testing.T) {
+	dir := writeHostsFile(t, `
+github.com:
+    oauth_token: gho_public
+ghe.example.com:
+    oauth_token: gho_enterprise
+keyring.example.com:
+    user: octocat
+`)
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "github.com", want: "gho_public"},
+		{host: "ghe.example.com", want: "gho_enterprise"},
+		{host: "keyring.example.com", want: ""},
+		{host: "unknown.example.com", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := readGHCLIToken(dir, tt.host); got != tt.want {
+				t.Errorf("readGHCLIToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := readGHCLIToken(t.TempDir(), "github.com"); got != "" {
+		t.Errorf("readGHCLIToken() without hosts.yml = %q, want empty", got)
+	}
+}
+
+func TestNewRequest_Authorization(t *testing.T) {
+	authenticated := NewActionsService(&ActionsServiceOptions{Token: "secret"})
+	req, err := authenticated.newRequest(context.Background(), "https://raw.githubusercontent.com/owner/repo/main/action.yml")
+	if err != nil {
+		t.Fatalf("newRequest() unexpected error: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization header = %q, want %q", got, "Bearer secret")
+	}
+
+	anonymous := NewActionsService(nil)
+	req, err = anonymous.newRequest(context.Background(), "https://raw.githubusercontent.com/owner/repo/main/action.yml")
+	if err != nil {
+		t.Fatalf("newRequest() unexpected error: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization header = %q, want empty for anonymous requests", got)
+	}
+}
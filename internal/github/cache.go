@@ -0,0 +1,147 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheTTL is the time after which cached responses for moving refs
+// (tags and branches) are revalidated with GitHub.
+const DefaultCacheTTL = 15 * time.Minute
+
+// Cache is an on-disk cache for files fetched from GitHub.
+//
+// Entries fetched at a full commit SHA are immutable and never revalidated.
+// Entries fetched at moving refs (tags and branches) are served from the cache
+// until their TTL expires, after which they are revalidated with GitHub using
+// the stored ETag (If-None-Match), so unchanged files are not downloaded again.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cacheKey identifies a cached response. Responses for the same URL differ by the requested media
+// type, e.g. the contents API returns the raw file or its JSON description, and by the credential
+// they were fetched with, so that a response fetched with one token is never served to another.
+type cacheKey struct {
+	URL    string
+	Accept string
+	// Credential is the SHA-256 hash of the Authorization header, or empty if there is none.
+	Credential string
+}
+
+// newCacheKey returns the key of the response to req.
+func newCacheKey(req *http.Request) cacheKey {
+	key := cacheKey{URL: req.URL.String(), Accept: req.Header.Get("Accept")}
+	if authorization := req.Header.Get("Authorization"); authorization != "" {
+		sum := sha256.Sum256([]byte(authorization))
+		key.Credential = hex.EncodeToString(sum[:])
+	}
+	return key
+}
+
+// cacheEntry is a single cached response, stored as JSON file named after its hashed key.
+type cacheEntry struct {
+	URL        string    `json:"url"`
+	Accept     string    `json:"accept,omitempty"`
+	Credential string    `json:"credential,omitempty"`
+	ETag       string    `json:"etag,omitempty"`
+	Immutable  bool      `json:"immutable,omitempty"`
+	FetchedAt  time.Time `json:"fetchedAt"`
+	Body       []byte    `json:"body"`
+}
+
+// DefaultCacheDir returns the default cache directory inside the user's cache directory,
+// e.g. ~/.cache/github-actions-utils-cli on Linux.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "github-actions-utils-cli"), nil
+}
+
+// NewCache creates a cache storing its entries in dir, creating the directory if needed.
+// If ttl is zero or negative, DefaultCacheTTL is used.
+func NewCache(dir string, ttl time.Duration) (*Cache, error) {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	// Cached files may originate from private repositories, so keep them private to the user
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %s: %w", dir, err)
+	}
+	return &Cache{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	}, nil
+}
+
+// key returns the key the entry is stored under.
+func (e *cacheEntry) key() cacheKey {
+	return cacheKey{URL: e.URL, Accept: e.Accept, Credential: e.Credential}
+}
+
+// path returns the file path of the entry for key.
+func (c *Cache) path(key cacheKey) string {
+	sum := sha256.Sum256([]byte(key.URL + "\n" + key.Accept + "\n" + key.Credential))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// get returns the cached entry for key, if any.
+// Unreadable or corrupt entries are treated as missing.
+func (c *Cache) get(key cacheKey) (*cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.key() != key {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// isFresh reports whether entry can be served without revalidating it with GitHub.
+func (c *Cache) isFresh(entry *cacheEntry) bool {
+	return entry.Immutable || c.now().Sub(entry.FetchedAt) < c.ttl
+}
+
+// put stores entry in the cache, replacing any existing entry with the same key.
+// The entry is written to a temporary file first, so concurrent readers never see partial entries.
+func (c *Cache) put(entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(entry.key()))
+}
+
+// touch marks entry as freshly validated and stores it.
+func (c *Cache) touch(entry *cacheEntry) error {
+	entry.FetchedAt = c.now()
+	return c.put(entry)
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+// newCachedTestService creates an ActionsService with a cache in a temporary directory,
+// whose raw content host is served by handler.
+func newCachedTestService(t *testing.T, handler http.Handler) (*ActionsService, *Cache) {
+	t.Helper()
+
+	cache, err := NewCache(t.TempDir(), time.Minute)
+	if err != nil {
+		t.Fatalf("NewCache() unexpected error: %v", err)
+	}
+
+	service := newTestService(t, handler, false)
+	service.cache = cache
+	return service, cache
+}
+
+func TestCache_ImmutableEntriesAreNeverRevalidated(t *testing.T) {
+	sha := "08c6903cd8c0fde910a37f88322edcfb5dd907a8"
+	requests := 0
+	service, cache := newCachedTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, "name: Checkout\n")
+	}))
+
+	for i := 0; i < 3; i++ {
+		data, err := service.FetchRawFile(context.Background(), "actions", "checkout", sha, "action.yml")
+		if err != nil {
+			t.Fatalf("FetchRawFile() unexpected error: %v", err)
+		}
+		if string(data) != "name: Checkout\n" {
+			t.Errorf("FetchRawFile() = %q, want %q", data, "name: Checkout\n")
+		}
+		// Even long after the TTL expired, the entry must be served from the cache
+		cache.now = func() time.Time { return time.Now().Add(24 * time.Hour) }
+	}
+
+	if requests != 1 {
+		t.Errorf("Server received %d requests, want 1", requests)
+	}
+}
+
+func TestCache_StaleEntriesAreRevalidatedWithETag(t *testing.T) {
+	requests := 0
+	notModified := 0
+	service, cache := newCachedTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "name: Setup\n")
+	}))
+
+	fetch := func() {
+		t.Helper()
+		data, err := service.FetchRawFile(context.Background(), "actions", "setup-node", "refs/tags/v4", "action.yml")
+		if err != nil {
+			t.Fatalf("FetchRawFile() unexpected error: %v", err)
+		}
+		if string(data) != "name: Setup\n" {
+			t.Errorf("FetchRawFile() = %q, want %q", data, "name: Setup\n")
+		}
+	}
+
+	// First fetch downloads the file, second one is served from the cache
+	fetch()
+	fetch()
+	if requests != 1 {
+		t.Fatalf("Server received %d requests, want 1 while the entry is fresh", requests)
+	}
+
+	// After the TTL expired, the entry is revalidated and the server answers with 304
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	fetch()
+	if requests != 2 || notModified != 1 {
+		t.Errorf("Server received %d requests with %d revalidations, want 2 and 1", requests, notModified)
+	}
+}
+
+func TestCache_EntriesAreKeyedByAcceptAndCredential(t *testing.T) {
+	requests := 0
+	service, _ := newCachedTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, "%s %s", r.Header.Get("Accept"), r.Header.Get("Authorization"))
+	}))
+
+	fetch := func(token, accept string) string {
+		t.Helper()
+		service.token = token
+		req, err := service.newRequest(context.Background(), service.endpoints.RawURL+"/actions/checkout/refs/tags/v5/action.yml")
+		if err != nil {
+			t.Fatalf("newRequest() unexpected error: %v", err)
+		}
+		req.Header.Set("Accept", accept)
+		resp, err := service.get(req, false)
+		if err != nil {
+			t.Fatalf("get() unexpected error: %v", err)
+		}
+		return string(resp.Body)
+	}
+
+	tests := []struct {
+		token  string
+		accept string
+		want   string
+	}{
+		{token: "token-a", accept: "application/vnd.github.raw", want: "application/vnd.github.raw Bearer token-a"},
+		{token: "token-a", accept: "application/vnd.github+json", want: "application/vnd.github+json Bearer token-a"},
+		{token: "token-b", accept: "application/vnd.github.raw", want: "application/vnd.github.raw Bearer token-b"},
+		{token: "", accept: "application/vnd.github.raw", want: "application/vnd.github.raw "},
+	}
+	// Each combination is fetched once, and served from the cache the second time
+	for range 2 {
+		for _, tt := range tests {
+			if got := fetch(tt.token, tt.accept); got != tt.want {
+				t.Errorf("get() with token %q and Accept %q = %q, want %q", tt.token, tt.accept, got, tt.want)
+			}
+		}
+	}
+	if requests != len(tests) {
+		t.Errorf("Server received %d requests, want %d", requests, len(tests))
+	}
+}
+
+func TestCache_ErrorsAreNotCached(t *testing.T) {
+	requests := 0
+	service, _ := newCachedTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+
+	for i := 0; i < 2; i++ {
+		if _, err := service.FetchRawFile(context.Background(), "actions", "missing", "refs/tags/v1", "action.yml"); err == nil {
+			t.Fatalf("FetchRawFile() expected error but got none")
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Server received %d requests, want 2", requests)
+	}
+}
+
+func TestCache_CorruptEntriesAreIgnored(t *testing.T) {
+	cache, err := NewCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewCache() unexpected error: %v", err)
+	}
+	if cache.ttl != DefaultCacheTTL {
+		t.Errorf("NewCache() ttl = %v, want %v", cache.ttl, DefaultCacheTTL)
+	}
+
+	key := cacheKey{URL: "https://raw.githubusercontent.com/actions/checkout/refs/tags/v5/action.yml"}
+	if err := cache.put(&cacheEntry{URL: key.URL, Body: []byte("ok")}); err != nil {
+		t.Fatalf("put() unexpected error: %v", err)
+	}
+	if _, ok := cache.get(key); !ok {
+		t.Fatalf("get() did not return stored entry")
+	}
+
+	if err := os.WriteFile(cache.path(key), []byte("not json"), 0o600); err != nil {
+		t.Fatalf("Failed to corrupt entry: %v", err)
+	}
+	if _, ok := cache.get(key); ok {
+		t.Errorf("get() returned corrupt entry")
+	}
+}
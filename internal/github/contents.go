@@ -0,0 +1,224 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxFileSize is the number of bytes of a file returned by GetRepositoryFile if no limit is given.
+const DefaultMaxFileSize = 256 * 1024
+
+// binarySniffLength is the number of leading bytes inspected to tell binary from text files.
+// Git uses the same length for its heuristic.
+const binarySniffLength = 8000
+
+// RepositoryFile is a file fetched from a repository by GetRepositoryFile.
+type RepositoryFile struct {
+	Path        string `json:"path" jsonschema:"The path of the file in the repository"`
+	Size        int    `json:"size" jsonschema:"The size of the file in bytes"`
+	ContentType string `json:"contentType" jsonschema:"The media type detected from the content, e.g. 'text/plain; charset=utf-8'"`
+	// Binary is set for files which are not UTF-8 text. Their content is omitted.
+	Binary bool `json:"binary" jsonschema:"Whether the file is binary; the content of binary files is omitted"`
+	// Truncated is set if the content was cut off at the size limit.
+	Truncated bool   `json:"truncated" jsonschema:"Whether the content was cut off at the size limit"`
+	Content   string `json:"content" jsonschema:"The text content of the file, empty for binary files"`
+}
+
+// Types of DirectoryEntry, as reported by the contents API.
+const (
+	EntryTypeFile      = "file"
+	EntryTypeDir       = "dir"
+	EntryTypeSymlink   = "symlink"
+	EntryTypeSubmodule = "submodule"
+)
+
+// DirectoryEntry is a file or directory listed by ListRepositoryDirectory.
+type DirectoryEntry struct {
+	Name string `json:"name" jsonschema:"The name of the entry"`
+	Path string `json:"path" jsonschema:"The path of the entry in the repository"`
+	Type string `json:"type" jsonschema:"One of file, dir, symlink or submodule"`
+	Size int    `json:"size" jsonschema:"The size of files in bytes, 0 for directories"`
+}
+
+// newContentsRef builds the reference of a path in a repository ("owner/repo") at a version,
+// which defaults to "main". The path is relative to the repository root; leading and trailing
+// slashes are ignored, and an empty path refers to the root directory.
+func newContentsRef(repository, filePath, version string) (*Ref, error) {
+	if strings.Contains(repository, "@") {
+		return nil, invalidRef("invalid repository '%s': expected 'owner/repo' without version", repository)
+	}
+	ref, err := ParseRepoRef(repository)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Path != "" {
+		return nil, invalidRef("invalid repository '%s': expected 'owner/repo' without path", repository)
+	}
+	if version != "" {
+		ref.Version = version
+	}
+
+	ref.Path = strings.Trim(filePath, "/")
+	if ref.Path != "" {
+		if err := validateRepoPath(ref.Path); err != nil {
+			return nil, invalidRef("invalid path '%s': %v", filePath, err)
+		}
+	}
+	return ref, nil
+}
+
+// GetRepositoryFile fetches a file from a GitHub repository (see FetchFile), e.g. the entry point
+// "dist/index.js" of an action or its "CHANGELOG.md". The version can be a tag, branch or commit SHA,
+// or a version spec such as "latest" (see ResolveActionVersion), and defaults to "main".
+//
+// At most maxSize bytes of content are returned (DefaultMaxFileSize if zero); longer files are truncated.
+// Binary files are detected from their content and returned without content.
+func (s *ActionsService) GetRepositoryFile(ctx context.Context, repository, filePath, version string, maxSize int) (*RepositoryFile, *ResolvedRef, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if maxSize <= 0 {
+		maxSize = DefaultMaxFileSize
+	}
+	ref, err := newContentsRef(repository, filePath, version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid file reference: %w", err)
+	}
+
+	// Resolve version specs to a release tag
+	var sha string
+	if IsVersionSpec(ref.Version) {
+		resolvedVersion, err := s.resolveVersion(ctx, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		ref.Version, sha = resolvedVersion.Tag, resolvedVersion.SHA
+	}
+
+	data, resolved, err := s.FetchFile(ctx, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved.SHA = sha
+
+	file := &RepositoryFile{
+		Path:        ref.Path,
+		Size:        len(data),
+		ContentType: http.DetectContentType(data),
+		Binary:      isBinary(data),
+	}
+	if !file.Binary {
+		file.Content, file.Truncated = truncateText(data, maxSize)
+	}
+	return file, resolved, nil
+}
+
+// isBinary reports whether data looks like the content of a binary file:
+// its first bytes contain a NUL byte or are not valid UTF-8.
+func isBinary(data []byte) bool {
+	sample := data[:min(len(data), binarySniffLength)]
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return true
+	}
+	cutOff := len(sample) < len(data)
+	for len(sample) > 0 {
+		r, size := utf8.DecodeRune(sample)
+		// A rune cut off at the end of the sample is not an error
+		if r == utf8.RuneError && size == 1 && (len(sample) >= utf8.UTFMax || !cutOff) {
+			return true
+		}
+		sample = sample[size:]
+	}
+	return false
+}
+
+// truncateText returns data as a string of at most maxSize bytes, without cutting a rune in half,
+// and whether it was truncated.
+func truncateText(data []byte, maxSize int) (string, bool) {
+	if len(data) <= maxSize {
+		return string(data), false
+	}
+	end := maxSize
+	for end > 0 && !utf8.RuneStart(data[end]) {
+		end--
+	}
+	return string(data[:end]), true
+}
+
+// ListRepositoryDirectory lists the files and subdirectories of a directory in a GitHub repository
+// using the REST API's contents endpoint, sorted by the API (directories are not listed recursively).
+// An empty path lists the root directory. The version can be a tag, branch or commit SHA, or a version
+// spec such as "latest" (see ResolveActionVersion), and defaults to "main".
+// The contents API lists at most 1,000 entries per directory.
+func (s *ActionsService) ListRepositoryDirectory(ctx context.Context, repository, dirPath, version string) ([]DirectoryEntry, *ResolvedRef, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	ref, err := newContentsRef(repository, dirPath, version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid directory reference: %w", err)
+	}
+
+	// Resolve version specs to a release tag
+	var sha string
+	if IsVersionSpec(ref.Version) {
+		resolvedVersion, err := s.resolveVersion(ctx, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		ref.Version, sha = resolvedVersion.Tag, resolvedVersion.SHA
+	}
+
+	var lastErr error
+	for _, candidate := range refCandidates(ref.Version) {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		entries, err := s.fetchDirectory(ctx, ref, candidate.URLPath)
+		if err == nil {
+			return entries, &ResolvedRef{Version: ref.Version, Type: candidate.Type, SHA: sha}, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, nil, err
+		}
+		lastErr = err
+	}
+	return nil, nil, lastErr
+}
+
+// fetchDirectory lists a directory at the given ref (see refCandidate) using the contents API.
+func (s *ActionsService) fetchDirectory(ctx context.Context, ref *Ref, urlPath string) ([]DirectoryEntry, error) {
+	query := url.Values{"ref": []string{urlPath}}
+	dirURL := fmt.Sprintf("%s/repos/%s/%s/contents/%s?%s", s.endpoints.APIURL, url.PathEscape(ref.Owner), url.PathEscape(ref.Repo), escapePath(ref.Path), query.Encode())
+
+	req, err := s.newRequest(ctx, dirURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", ref, err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := s.get(req, IsFullCommitSHA(urlPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", ref, err)
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to list %s: %w", ref, newHTTPError(dirURL, resp))
+	}
+
+	// Files are returned as a single object instead of an array
+	if trimmed := bytes.TrimSpace(resp.Body); len(trimmed) > 0 && trimmed[0] == '{' {
+		return nil, invalidRef("'%s' is a file, not a directory", ref.Path)
+	}
+	entries := []DirectoryEntry{}
+	if err := json.Unmarshal(resp.Body, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse contents of %s: %w", ref, err)
+	}
+	return entries, nil
+}
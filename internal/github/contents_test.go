@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetRepositoryFile(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/raw/actions/example/refs/tags/v1/CHANGELOG.md":
+			fmt.Fprint(w, "# Changelog\n\n## v1.0.0 – ünïcode\n")
+		case "/raw/actions/example/refs/heads/main/dist/logo.png":
+			w.Write([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"))
+		default:
+			http.NotFound(w, r)
+		}
+	}), false)
+
+	tests := []struct {
+		name          string
+		path          string
+		version       string
+		maxSize       int
+		wantContent   string
+		wantBinary    bool
+		wantTruncated bool
+		wantType      RefType
+	}{
+		{name: "text file", path: "CHANGELOG.md", version: "v1", wantContent: "# Changelog\n\n## v1.0.0 – ünïcode\n", wantType: RefTypeTag},
+		{name: "leading slash", path: "/CHANGELOG.md", version: "v1", wantContent: "# Changelog\n\n## v1.0.0 – ünïcode\n", wantType: RefTypeTag},
+		{name: "truncated within a rune", path: "CHANGELOG.md", version: "v1", maxSize: 28, wantContent: "# Changelog\n\n## v1.0.0 – ü"[:27], wantTruncated: true, wantType: RefTypeTag},
+		{name: "binary file on the default branch", path: "dist/logo.png", wantBinary: true, wantType: RefTypeBranch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, resolved, err := service.GetRepositoryFile(context.Background(), "actions/example", tt.path, tt.version, tt.maxSize)
+			if err != nil {
+				t.Fatalf("GetRepositoryFile() unexpected error: %v", err)
+			}
+			if file.Content != tt.wantContent || file.Binary != tt.wantBinary || file.Truncated != tt.wantTruncated {
+				t.Errorf("GetRepositoryFile() = %+v, want content %q, binary %v, truncated %v", file, tt.wantContent, tt.wantBinary, tt.wantTruncated)
+			}
+			if resolved.Type != tt.wantType {
+				t.Errorf("GetRepositoryFile() resolved = %+v, want type %s", resolved, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestGetRepositoryFile_InvalidReference(t *testing.T) {
+	service := NewActionsService(nil)
+
+	tests := []struct {
+		name       string
+		repository string
+		path       string
+	}{
+		{name: "missing path", repository: "actions/example", path: ""},
+		{name: "parent directory", repository: "actions/example", path: "../secrets"},
+		{name: "repository with version", repository: "actions/example@v1", path: "README.md"},
+		{name: "repository with path", repository: "actions/example/dist", path: "index.js"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := service.GetRepositoryFile(context.Background(), tt.repository, tt.path, "", 0); !errors.Is(err, ErrInvalidRef) {
+				t.Errorf("GetRepositoryFile() error = %v, want ErrInvalidRef", err)
+			}
+		})
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{name: "empty", data: nil, want: false},
+		{name: "ASCII", data: []byte("hello\n"), want: false},
+		{name: "UTF-8", data: []byte("grüße\n"), want: false},
+		{name: "NUL byte", data: []byte("a\x00b"), want: true},
+		{name: "invalid UTF-8", data: []byte("caf\xe9 au lait"), want: true},
+		{name: "truncated rune at end of file", data: []byte("caf\xc3"), want: true},
+		{name: "rune cut off by the sample", data: []byte(strings.Repeat("a", binarySniffLength-1) + "ü"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBinary(tt.data); got != tt.want {
+				t.Errorf("isBinary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListRepositoryDirectory(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v3/repos/actions/example/contents/.github/workflows" && r.URL.Query().Get("ref") == "refs/heads/main":
+			fmt.Fprint(w, `[{"name":"ci.yml","path":".github/workflows/ci.yml","type":"file","size":512,"sha":"abc"},{"name":"scripts","path":".github/workflows/scripts","type":"dir","size":0}]`)
+		case r.URL.Path == "/api/v3/repos/actions/example/contents/action.yml":
+			fmt.Fprint(w, `{"name":"action.yml","path":"action.yml","type":"file","size":100}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}), false)
+
+	entries, resolved, err := service.ListRepositoryDirectory(context.Background(), "actions/example", ".github/workflows/", "")
+	if err != nil {
+		t.Fatalf("ListRepositoryDirectory() unexpected error: %v", err)
+	}
+	want := []DirectoryEntry{
+		{Name: "ci.yml", Path: ".github/workflows/ci.yml", Type: EntryTypeFile, Size: 512},
+		{Name: "scripts", Path: ".github/workflows/scripts", Type: EntryTypeDir},
+	}
+	if fmt.Sprint(entries) != fmt.Sprint(want) {
+		t.Errorf("ListRepositoryDirectory() = %+v, want %+v", entries, want)
+	}
+	if resolved.Type != RefTypeBranch {
+		t.Errorf("ListRepositoryDirectory() resolved = %+v, want a branch", resolved)
+	}
+
+	if _, _, err := service.ListRepositoryDirectory(context.Background(), "actions/example", "action.yml", "main"); !errors.Is(err, ErrInvalidRef) {
+		t.Errorf("ListRepositoryDirectory() error = %v, want ErrInvalidRef for a file", err)
+	}
+	if _, _, err := service.ListRepositoryDirectory(context.Background(), "actions/example", "missing", "main"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ListRepositoryDirectory() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Defaults for DependencyTreeOptions.
+const (
+	DefaultDependencyTreeDepth       = 5
+	DefaultDependencyTreeConcurrency = 4
+)
+
+// Kinds of DependencyNode.
+const (
+	// DependencyKindAction is an action in a GitHub repository.
+	DependencyKindAction = "action"
+	// DependencyKindLocal is an action referenced by a path in the workspace ("./..."), which cannot be resolved remotely.
+	DependencyKindLocal = "local"
+	// DependencyKindDocker is a Docker image ("docker://...").
+	DependencyKindDocker = "docker"
+)
+
+// DependencyNode is an action in a dependency tree, together with the actions it uses.
+// Only composite actions use other actions.
+type DependencyNode struct {
+	Uses        string       `json:"uses" jsonschema:"The action reference as written in uses:, e.g. 'actions/checkout@v4'"`
+	Kind        string       `json:"kind" jsonschema:"One of action, local or docker"`
+	Runtime     string       `json:"runtime,omitempty" jsonschema:"The runtime of the action (runs.using), e.g. node20, docker or composite"`
+	ResolvedRef *ResolvedRef `json:"resolvedRef,omitempty" jsonschema:"The version and kind of ref the action reference was resolved to"`
+	// Dependencies are the actions used by the steps of a composite action, in step order.
+	Dependencies []*DependencyNode `json:"dependencies,omitempty" jsonschema:"The actions used by the steps of a composite action, in step order"`
+	// Cycle is set if the action is one of its own ancestors; its dependencies are not expanded again.
+	Cycle bool `json:"cycle,omitempty" jsonschema:"Whether the action is one of its own ancestors; its dependencies are not expanded again"`
+	// Truncated is set if the action has dependencies which were not expanded because of the depth limit.
+	Truncated bool `json:"truncated,omitempty" jsonschema:"Whether the action has dependencies which were not expanded because of the depth limit"`
+	// Error reports why the action could not be fetched.
+	Error string `json:"error,omitempty" jsonschema:"Why the action could not be fetched"`
+}
+
+// Walk calls fn for every node of the tree in depth-first order, with the depth of the node below n.
+func (n *DependencyNode) Walk(fn func(node *DependencyNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *DependencyNode) walk(fn func(node *DependencyNode, depth int), depth int) {
+	fn(n, depth)
+	for _, dependency := range n.Dependencies {
+		dependency.walk(fn, depth+1)
+	}
+}
+
+// DependencyTreeOptions configures GetActionDependencyTree.
+type DependencyTreeOptions struct {
+	// MaxDepth limits how many levels of nested actions are expanded below the root.
+	// If zero, DefaultDependencyTreeDepth is used.
+	MaxDepth int
+	// Concurrency limits how many actions are fetched at the same time.
+	// If zero, DefaultDependencyTreeConcurrency is used.
+	Concurrency int
+}
+
+// GetActionDependencyTree fetches an action and, recursively, every action used by the steps
+// of composite actions (see GetActionParameters), returning the tree of actions with their runtimes.
+//
+// Local actions ("./...") are read from the workspace (see Workspace); without a workspace, they are not expanded.
+// Actions are fetched concurrently and only once, even if they are used several times in the tree.
+// Actions which are their own ancestors are marked as cycles and not expanded again, and expansion
+// stops at the configured depth. Nested actions which cannot be fetched are reported in the
+// node's Error; an error is only returned if the root action cannot be fetched.
+func (s *ActionsService) GetActionDependencyTree(ctx context.Context, actionRef string, opts *DependencyTreeOptions) (*DependencyNode, error) {
+	if opts == nil {
+		opts = &DependencyTreeOptions{}
+	}
+	maxDepth := opts.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultDependencyTreeDepth
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultDependencyTreeConcurrency
+	}
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	resolver := &dependencyResolver{
+		service:   s,
+		maxDepth:  maxDepth,
+		semaphore: make(chan struct{}, concurrency),
+		fetches:   map[string]*dependencyFetch{},
+	}
+
+	root := &DependencyNode{Uses: strings.TrimSpace(actionRef), Kind: dependencyKind(actionRef)}
+	fetch := resolver.fetch(ctx, root.Uses)
+	if fetch.err != nil {
+		return nil, fetch.err
+	}
+	resolver.expand(ctx, root, fetch, 0, map[string]bool{dependencyKey(root.Uses): true})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// dependencyFetch is the memoized result of fetching an action.
+type dependencyFetch struct {
+	once     sync.Once
+	metadata *ActionMetadata
+	resolved *ResolvedRef
+	err      error
+}
+
+// dependencyResolver expands dependency trees, fetching every action at most once.
+type dependencyResolver struct {
+	service   *ActionsService
+	maxDepth  int
+	semaphore chan struct{}
+
+	mu      sync.Mutex
+	fetches map[string]*dependencyFetch
+}
+
+// fetch fetches an action, waiting for a free slot of the concurrency limit.
+// Concurrent calls for the same action share a single request.
+func (r *dependencyResolver) fetch(ctx context.Context, uses string) *dependencyFetch {
+	r.mu.Lock()
+	fetch, ok := r.fetches[dependencyKey(uses)]
+	if !ok {
+		fetch = &dependencyFetch{}
+		r.fetches[dependencyKey(uses)] = fetch
+	}
+	r.mu.Unlock()
+
+	fetch.once.Do(func() {
+		select {
+		case r.semaphore <- struct{}{}:
+			defer func() { <-r.semaphore }()
+		case <-ctx.Done():
+			fetch.err = ctx.Err()
+			return
+		}
+		fetch.metadata, fetch.resolved, fetch.err = r.service.GetActionParameters(ctx, uses)
+	})
+	return fetch
+}
+
+// expand fills in a fetched node and its dependencies. ancestors holds the keys of the node and all its ancestors.
+func (r *dependencyResolver) expand(ctx context.Context, node *DependencyNode, fetch *dependencyFetch, depth int, ancestors map[string]bool) {
+	if fetch.err != nil {
+		node.Error = fetch.err.Error()
+		return
+	}
+	node.Runtime = fetch.metadata.Runs.Using
+	node.ResolvedRef = fetch.resolved
+
+	var uses []string
+	for _, step := range fetch.metadata.Runs.Steps {
+		if step.Uses != "" {
+			uses = append(uses, strings.TrimSpace(step.Uses))
+		}
+	}
+	if len(uses) == 0 {
+		return
+	}
+	if depth >= r.maxDepth {
+		node.Truncated = true
+		return
+	}
+
+	node.Dependencies = make([]*DependencyNode, len(uses))
+	var wg sync.WaitGroup
+	for i, dependencyUses := range uses {
+		dependency := &DependencyNode{Uses: dependencyUses, Kind: dependencyKind(dependencyUses)}
+		node.Dependencies[i] = dependency
+
+		switch key := dependencyKey(dependencyUses); {
+		case dependency.Kind == DependencyKindLocal && r.service.Workspace(ctx) == "":
+			// Local actions of composite actions refer to the workspace of the calling workflow,
+			// which can only be resolved if it is known
+		case dependency.Kind == DependencyKindDocker:
+			dependency.Runtime = RunsUsingDocker
+		case ancestors[key]:
+			dependency.Cycle = true
+		default:
+			childAncestors := make(map[string]bool, len(ancestors)+1)
+			for ancestor := range ancestors {
+				childAncestors[ancestor] = true
+			}
+			childAncestors[key] = true
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r.expand(ctx, dependency, r.fetch(ctx, dependencyUses), depth+1, childAncestors)
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+// dependencyKind returns the kind of a "uses:" reference.
+func dependencyKind(uses string) string {
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(uses), "./"):
+		return DependencyKindLocal
+	case IsDockerRef(uses):
+		return DependencyKindDocker
+	default:
+		return DependencyKindAction
+	}
+}
+
+// dependencyKey identifies an action for cycle detection and deduplication.
+// Owners and repositories are case-insensitive on GitHub.
+func dependencyKey(uses string) string {
+	if localPath, err := ParseLocalRef(uses); err == nil {
+		return LocalRefPrefix + localPath
+	}
+	ref, err := ParseActionRef(uses)
+	if err != nil {
+		return strings.TrimSpace(uses)
+	}
+	return strings.ToLower(ref.Owner+"/"+ref.Repo) + "/" + ref.Path + "@" + ref.Version
+}
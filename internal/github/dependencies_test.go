@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// compositeActionsHandler serves the action.yml files of a set of actions, keyed by "owner/repo",
+// at the tag v1, and counts the requests per action.
+func compositeActionsHandler(actions map[string]string, requests map[string]int, mu *sync.Mutex) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for name, content := range actions {
+			if r.URL.Path == "/raw/"+name+"/refs/tags/v1/action.yml" {
+				mu.Lock()
+				requests[name]++
+				mu.Unlock()
+				fmt.Fprint(w, content)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}
+}
+
+func TestGetActionDependencyTree(t *testing.T) {
+	actions := map[string]string{
+		"owner/root": `name: Root
+runs:
+  using: composite
+  steps:
+    - uses: actions/checkout@v1
+    - run: make
+      shell: bash
+    - uses: ./.github/actions/local
+    - uses: docker://alpine:3.20
+    - uses: owner/inner@v1
+    - uses: owner/missing@v1
+`,
+		"owner/inner": `name: Inner
+runs:
+  using: composite
+  steps:
+    - uses: actions/checkout@v1
+    - uses: Owner/Root@v1
+`,
+		"actions/checkout": "name: Checkout\nruns:\n  using: node20\n  main: index.js\n",
+	}
+	requests := map[string]int{}
+	var mu sync.Mutex
+	service := newTestService(t, compositeActionsHandler(actions, requests, &mu), false)
+
+	tree, err := service.GetActionDependencyTree(context.Background(), "owner/root@v1", nil)
+	if err != nil {
+		t.Fatalf("GetActionDependencyTree() unexpected error: %v", err)
+	}
+
+	var got []string
+	tree.Walk(func(node *DependencyNode, depth int) {
+		line := fmt.Sprintf("%s%s (%s", strings.Repeat("  ", depth), node.Uses, node.Kind)
+		if node.Runtime != "" {
+			line += ", " + node.Runtime
+		}
+		if node.Cycle {
+			line += ", cycle"
+		}
+		if node.Error != "" {
+			line += ", error"
+		}
+		got = append(got, line+")")
+	})
+	want := []string{
+		"owner/root@v1 (action, composite)",
+		"  actions/checkout@v1 (action, node20)",
+		"  ./.github/actions/local (local)",
+		"  docker://alpine:3.20 (docker, docker)",
+		"  owner/inner@v1 (action, composite)",
+		"    actions/checkout@v1 (action, node20)",
+		"    Owner/Root@v1 (action, cycle)",
+		"  owner/missing@v1 (action, error)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("GetActionDependencyTree() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Actions used several times are only fetched once
+	if requests["actions/checkout"] != 1 || requests["owner/root"] != 1 {
+		t.Errorf("Requests = %v, want a single request per action", requests)
+	}
+}
+
+func TestGetActionDependencyTree_MaxDepth(t *testing.T) {
+	actions := map[string]string{
+		"owner/root":  "name: Root\nruns:\n  using: composite\n  steps:\n    - uses: owner/inner@v1\n",
+		"owner/inner": "name: Inner\nruns:\n  using: composite\n  steps:\n    - uses: owner/leaf@v1\n",
+		"owner/leaf":  "name: Leaf\nruns:\n  using: node20\n  main: index.js\n",
+	}
+	service := newTestService(t, compositeActionsHandler(actions, map[string]int{}, &sync.Mutex{}), false)
+
+	tree, err := service.GetActionDependencyTree(context.Background(), "owner/root@v1", &DependencyTreeOptions{MaxDepth: 1, Concurrency: 1})
+	if err != nil {
+		t.Fatalf("GetActionDependencyTree() unexpected error: %v", err)
+	}
+
+	if len(tree.Dependencies) != 1 {
+		t.Fatalf("Dependencies = %+v, want owner/inner@v1", tree.Dependencies)
+	}
+	inner := tree.Dependencies[0]
+	if !inner.Truncated || len(inner.Dependencies) != 0 {
+		t.Errorf("Dependencies[0] = %+v, want a truncated node without dependencies", inner)
+	}
+}
+
+func TestGetActionDependencyTree_RootNotFound(t *testing.T) {
+	service := newTestService(t, http.NotFoundHandler(), false)
+
+	if _, err := service.GetActionDependencyTree(context.Background(), "owner/missing@v1", nil); err == nil {
+		t.Errorf("GetActionDependencyTree() expected error but got none")
+	}
+}
+
+func TestGetActionDependencyTree_LocalActions(t *testing.T) {
+	workspace := newTestWorkspace(t, map[string]string{
+		".github/actions/setup/action.yml": "name: Setup\nruns:\n  using: composite\n  steps:\n    - uses: ./.github/actions/tool/\n    - uses: owner/leaf@v1\n",
+		".github/actions/tool/action.yml":  "name: Tool\nruns:\n  using: composite\n  steps:\n    - uses: ./.github/actions/setup\n",
+	})
+	actions := map[string]string{"owner/leaf": "name: Leaf\nruns:\n  using: node24\n  main: index.js\n"}
+	service := newTestService(t, compositeActionsHandler(actions, map[string]int{}, &sync.Mutex{}), false)
+	ctx := WithWorkspace(context.Background(), workspace)
+
+	tree, err := service.GetActionDependencyTree(ctx, "./.github/actions/setup", nil)
+	if err != nil {
+		t.Fatalf("GetActionDependencyTree() unexpected error: %v", err)
+	}
+
+	if len(tree.Dependencies) != 2 {
+		t.Fatalf("Dependencies = %+v, want the local tool action and owner/leaf@v1", tree.Dependencies)
+	}
+	tool, leaf := tree.Dependencies[0], tree.Dependencies[1]
+	if tool.Kind != DependencyKindLocal || tool.Runtime != "composite" || len(tool.Dependencies) != 1 || !tool.Dependencies[0].Cycle {
+		t.Errorf("Dependencies[0] = %+v, want the expanded local action using the root as a cycle", tool)
+	}
+	if leaf.Runtime != "node24" {
+		t.Errorf("Dependencies[1] = %+v, want owner/leaf@v1 on node24", leaf)
+	}
+}
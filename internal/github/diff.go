@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ActionDiff describes how the interface of an action changed between two versions.
+// Name lists are sorted alphabetically.
+type ActionDiff struct {
+	From *ResolvedRef `json:"from" jsonschema:"The ref the old version was resolved to"`
+	To   *ResolvedRef `json:"to" jsonschema:"The ref the new version was resolved to"`
+
+	AddedInputs            []string            `json:"addedInputs" jsonschema:"Inputs which only exist in the new version"`
+	RemovedInputs          []string            `json:"removedInputs" jsonschema:"Inputs which only exist in the old version"`
+	RenamedInputs          []InputRename       `json:"renamedInputs" jsonschema:"Inputs which were likely renamed, detected by matching names or descriptions"`
+	ChangedDefaults        []DefaultChange     `json:"changedDefaults" jsonschema:"Inputs whose default value changed"`
+	NewlyRequiredInputs    []string            `json:"newlyRequiredInputs" jsonschema:"Inputs which are required in the new version only, including added required inputs"`
+	NoLongerRequiredInputs []string            `json:"noLongerRequiredInputs" jsonschema:"Inputs which are required in the old version only"`
+	NewlyDeprecatedInputs  []DeprecationChange `json:"newlyDeprecatedInputs" jsonschema:"Inputs with a deprecation message that is new in the new version"`
+	AddedOutputs           []string            `json:"addedOutputs" jsonschema:"Outputs which only exist in the new version"`
+	RemovedOutputs         []string            `json:"removedOutputs" jsonschema:"Outputs which only exist in the old version"`
+	RuntimeChange          *RuntimeChange      `json:"runtimeChange,omitempty" jsonschema:"The change of runs.using, e.g. from node16 to node20"`
+}
+
+// InputRename is an input which was likely renamed between two versions.
+type InputRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DefaultChange is a changed default value of an input.
+type DefaultChange struct {
+	Input string `json:"input"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// DeprecationChange is an input that was deprecated in the new version.
+type DeprecationChange struct {
+	Input   string `json:"input"`
+	Message string `json:"message"`
+}
+
+// RuntimeChange is a change of the runtime an action runs on.
+type RuntimeChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// HasChanges reports whether the interface of the action changed at all.
+func (d *ActionDiff) HasChanges() bool {
+	return len(d.AddedInputs) > 0 || len(d.RemovedInputs) > 0 || len(d.RenamedInputs) > 0 ||
+		len(d.ChangedDefaults) > 0 || len(d.NewlyRequiredInputs) > 0 || len(d.NoLongerRequiredInputs) > 0 ||
+		len(d.NewlyDeprecatedInputs) > 0 || len(d.AddedOutputs) > 0 || len(d.RemovedOutputs) > 0 ||
+		d.RuntimeChange != nil
+}
+
+// IsBreaking reports whether workflows using the old version may fail with the new one,
+// i.e. inputs or outputs were removed or renamed, or inputs became required.
+func (d *ActionDiff) IsBreaking() bool {
+	return len(d.RemovedInputs) > 0 || len(d.RenamedInputs) > 0 || len(d.NewlyRequiredInputs) > 0 || len(d.RemovedOutputs) > 0
+}
+
+// String formats the diff as a human-readable report.
+func (d *ActionDiff) String() string {
+	var b strings.Builder
+	if d.From != nil && d.To != nil {
+		fmt.Fprintf(&b, "Changes from %s to %s:\n", d.From.Version, d.To.Version)
+	}
+	if !d.HasChanges() {
+		b.WriteString("No changes to inputs, outputs or runtime.\n")
+		return b.String()
+	}
+
+	if d.RuntimeChange != nil {
+		fmt.Fprintf(&b, "\nRuntime: %s -> %s\n", d.RuntimeChange.From, d.RuntimeChange.To)
+	}
+
+	writeList := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n", title)
+		for _, item := range items {
+			fmt.Fprintf(&b, "  - %s\n", item)
+		}
+	}
+
+	writeList("Added inputs", d.AddedInputs)
+	writeList("Removed inputs", d.RemovedInputs)
+	var renamed []string
+	for _, rename := range d.RenamedInputs {
+		renamed = append(renamed, fmt.Sprintf("%s -> %s", rename.From, rename.To))
+	}
+	writeList("Renamed inputs", renamed)
+	writeList("Newly required inputs", d.NewlyRequiredInputs)
+	writeList("No longer required inputs", d.NoLongerRequiredInputs)
+	var defaults []string
+	for _, change := range d.ChangedDefaults {
+		defaults = append(defaults, fmt.Sprintf("%s: %q -> %q", change.Input, change.From, change.To))
+	}
+	writeList("Changed defaults", defaults)
+	var deprecated []string
+	for _, change := range d.NewlyDeprecatedInputs {
+		deprecated = append(deprecated, fmt.Sprintf("%s: %s", change.Input, change.Message))
+	}
+	writeList("Newly deprecated inputs", deprecated)
+	writeList("Added outputs", d.AddedOutputs)
+	writeList("Removed outputs", d.RemovedOutputs)
+
+	if d.IsBreaking() {
+		b.WriteString("\nThis update contains breaking changes.\n")
+	}
+	return b.String()
+}
+
+// DiffActionMetadata compares the interfaces of two versions of an action.
+// Inputs that were removed and added at the same time are reported as renamed if
+// their names only differ in case and separators (e.g. "fetch_depth" and "fetch-depth")
+// or if they have the same non-empty description.
+func DiffActionMetadata(from, to *ActionMetadata) *ActionDiff {
+	diff := &ActionDiff{
+		AddedInputs:            []string{},
+		RemovedInputs:          []string{},
+		RenamedInputs:          []InputRename{},
+		ChangedDefaults:        []DefaultChange{},
+		NewlyRequiredInputs:    []string{},
+		NoLongerRequiredInputs: []string{},
+		NewlyDeprecatedInputs:  []DeprecationChange{},
+		AddedOutputs:           addedKeys(from.Outputs, to.Outputs),
+		RemovedOutputs:         addedKeys(to.Outputs, from.Outputs),
+	}
+
+	if from.Runs.Using != to.Runs.Using {
+		diff.RuntimeChange = &RuntimeChange{From: from.Runs.Using, To: to.Runs.Using}
+	}
+
+	added := addedKeys(from.Inputs, to.Inputs)
+	removed := addedKeys(to.Inputs, from.Inputs)
+
+	// Pair up removed and added inputs which are likely the same input under a new name
+	renamedTo := map[string]string{}
+	for _, oldName := range removed {
+		for _, newName := range added {
+			if _, taken := renamedTo[newName]; taken {
+				continue
+			}
+			if isLikelyRename(oldName, from.Inputs[oldName], newName, to.Inputs[newName]) {
+				renamedTo[newName] = oldName
+				diff.RenamedInputs = append(diff.RenamedInputs, InputRename{From: oldName, To: newName})
+				break
+			}
+		}
+	}
+	for _, name := range added {
+		if _, ok := renamedTo[name]; !ok {
+			diff.AddedInputs = append(diff.AddedInputs, name)
+		}
+	}
+	for _, name := range removed {
+		if !slices.ContainsFunc(diff.RenamedInputs, func(rename InputRename) bool { return rename.From == name }) {
+			diff.RemovedInputs = append(diff.RemovedInputs, name)
+		}
+	}
+
+	// Compare inputs existing in both versions, treating renamed inputs as the same input
+	for _, newName := range slices.Sorted(maps.Keys(to.Inputs)) {
+		oldName, ok := renamedTo[newName]
+		if !ok {
+			oldName = newName
+		}
+		newInput := to.Inputs[newName]
+		oldInput, existed := from.Inputs[oldName]
+
+		if newInput.Required && (!existed || !oldInput.Required) {
+			diff.NewlyRequiredInputs = append(diff.NewlyRequiredInputs, newName)
+		}
+		if !existed {
+			continue
+		}
+		if oldInput.Required && !newInput.Required {
+			diff.NoLongerRequiredInputs = append(diff.NoLongerRequiredInputs, newName)
+		}
+		if oldInput.Default != newInput.Default {
+			diff.ChangedDefaults = append(diff.ChangedDefaults, DefaultChange{Input: newName, From: oldInput.Default, To: newInput.Default})
+		}
+		if newInput.DeprecationMessage != "" && newInput.DeprecationMessage != oldInput.DeprecationMessage {
+			diff.NewlyDeprecatedInputs = append(diff.NewlyDeprecatedInputs, DeprecationChange{Input: newName, Message: newInput.DeprecationMessage})
+		}
+	}
+
+	return diff
+}
+
+// isLikelyRename reports whether an input removed in one version and an input added
+// in the next are likely the same input under a different name.
+func isLikelyRename(oldName string, oldInput ActionInput, newName string, newInput ActionInput) bool {
+	if normalizeInputName(oldName) == normalizeInputName(newName) {
+		return true
+	}
+	description := strings.TrimSpace(oldInput.Description)
+	return description != "" && description == strings.TrimSpace(newInput.Description)
+}
+
+// normalizeInputName lowercases an input name and removes separators.
+func normalizeInputName(name string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(name))
+}
+
+// addedKeys returns the keys of to which do not exist in from, sorted alphabetically.
+func addedKeys[V any](from, to map[string]V) []string {
+	added := []string{}
+	for _, key := range slices.Sorted(maps.Keys(to)) {
+		if _, ok := from[key]; !ok {
+			added = append(added, key)
+		}
+	}
+	return added
+}
+
+// DiffActionVersions compares the interfaces of two versions of an action.
+// The action is given without version (e.g. "docker/build-push-action" or "github/codeql-action/init"),
+// and each version may be anything accepted by GetActionParameters, including specs such as "latest".
+func (s *ActionsService) DiffActionVersions(ctx context.Context, action, fromVersion, toVersion string) (*ActionDiff, error) {
+	from, fromRef, err := s.GetActionParameters(ctx, action+"@"+fromVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s@%s: %w", action, fromVersion, err)
+	}
+	to, toRef, err := s.GetActionParameters(ctx, action+"@"+toVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s@%s: %w", action, toVersion, err)
+	}
+
+	diff := DiffActionMetadata(from, to)
+	diff.From = fromRef
+	diff.To = toRef
+	return diff, nil
+}
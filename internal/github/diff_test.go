@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffActionMetadata(t *testing.T) {
+	from := &ActionMetadata{
+		Inputs: map[string]ActionInput{
+			"context":     {Description: "Build's context"},
+			"push":        {Description: "Push is a shorthand for --output=type=registry", Default: "false"},
+			"build_args":  {Description: "List of build-time variables"},
+			"tag":         {Description: "Tag to apply"},
+			"load":        {Description: "Load is a shorthand for --output=type=docker"},
+			"cache-from":  {Description: "List of external cache sources", Required: true},
+			"github-auth": {Description: "GitHub token"},
+		},
+		Outputs: map[string]ActionOutput{
+			"imageid": {Description: "Image ID"},
+			"digest":  {Description: "Image digest"},
+		},
+		Runs: ActionRuns{Using: "node16"},
+	}
+	to := &ActionMetadata{
+		Inputs: map[string]ActionInput{
+			"context":    {Description: "Build's context"},
+			"push":       {Description: "Push is a shorthand for --output=type=registry", Default: "true"},
+			"build-args": {Description: "List of build-time variables"},
+			"tags":       {Description: "Tag to apply"},
+			"load":       {Description: "Load is a shorthand for --output=type=docker", DeprecationMessage: "Use outputs instead"},
+			"cache-from": {Description: "List of external cache sources"},
+			"platforms":  {Description: "List of target platforms", Required: true},
+			"secrets":    {Description: "List of secrets"},
+		},
+		Outputs: map[string]ActionOutput{
+			"imageid":  {Description: "Image ID"},
+			"metadata": {Description: "Build result metadata"},
+		},
+		Runs: ActionRuns{Using: "node20"},
+	}
+
+	got := DiffActionMetadata(from, to)
+
+	want := &ActionDiff{
+		AddedInputs:   []string{"platforms", "secrets"},
+		RemovedInputs: []string{"github-auth"},
+		RenamedInputs: []InputRename{
+			{From: "build_args", To: "build-args"},
+			{From: "tag", To: "tags"},
+		},
+		ChangedDefaults:        []DefaultChange{{Input: "push", From: "false", To: "true"}},
+		NewlyRequiredInputs:    []string{"platforms"},
+		NoLongerRequiredInputs: []string{"cache-from"},
+		NewlyDeprecatedInputs:  []DeprecationChange{{Input: "load", Message: "Use outputs instead"}},
+		AddedOutputs:           []string{"metadata"},
+		RemovedOutputs:         []string{"digest"},
+		RuntimeChange:          &RuntimeChange{From: "node16", To: "node20"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffActionMetadata() =\n%+v\nwant\n%+v", got, want)
+	}
+	if !got.IsBreaking() {
+		t.Errorf("IsBreaking() = false, want true")
+	}
+}
+
+func TestDiffActionMetadata_NoChanges(t *testing.T) {
+	metadata := &ActionMetadata{
+		Inputs:  map[string]ActionInput{"path": {Description: "Path", Default: "."}},
+		Outputs: map[string]ActionOutput{"ref": {Description: "Ref"}},
+		Runs:    ActionRuns{Using: "node20"},
+	}
+
+	diff := DiffActionMetadata(metadata, metadata)
+	if diff.HasChanges() {
+		t.Errorf("HasChanges() = true, want false for identical metadata: %+v", diff)
+	}
+	if !strings.Contains(diff.String(), "No changes") {
+		t.Errorf("String() = %q, want it to report no changes", diff.String())
+	}
+}
+
+func TestActionDiff_String(t *testing.T) {
+	diff := &ActionDiff{
+		From:                &ResolvedRef{Version: "v5", Type: RefTypeTag},
+		To:                  &ResolvedRef{Version: "v6", Type: RefTypeTag},
+		AddedInputs:         []string{"platforms"},
+		NewlyRequiredInputs: []string{"platforms"},
+		ChangedDefaults:     []DefaultChange{{Input: "push", From: "false", To: "true"}},
+		RuntimeChange:       &RuntimeChange{From: "node16", To: "node20"},
+	}
+
+	got := diff.String()
+	for _, want := range []string{
+		"Changes from v5 to v6:",
+		"Runtime: node16 -> node20",
+		"Added inputs:\n  - platforms",
+		"Newly required inputs:\n  - platforms",
+		`push: "false" -> "true"`,
+		"breaking changes",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestDiffActionVersions(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/raw/docker/build-push-action/refs/tags/v5/action.yml":
+			fmt.Fprint(w, "name: Build\ninputs:\n  push:\n    default: 'false'\nruns:\n  using: node16\n")
+		case "/raw/docker/build-push-action/refs/tags/v6/action.yml":
+			fmt.Fprint(w, "name: Build\ninputs:\n  push:\n    default: 'false'\n  sbom:\n    required: true\nruns:\n  using: node20\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}), false)
+
+	diff, err := service.DiffActionVersions(context.Background(), "docker/build-push-action", "v5", "v6")
+	if err != nil {
+		t.Fatalf("DiffActionVersions() unexpected error: %v", err)
+	}
+	if diff.From.Version != "v5" || diff.To.Version != "v6" {
+		t.Errorf("DiffActionVersions() from/to = %s/%s, want v5/v6", diff.From.Version, diff.To.Version)
+	}
+	if !reflect.DeepEqual(diff.AddedInputs, []string{"sbom"}) || !reflect.DeepEqual(diff.NewlyRequiredInputs, []string{"sbom"}) {
+		t.Errorf("DiffActionVersions() added = %v, newly required = %v, want [sbom]", diff.AddedInputs, diff.NewlyRequiredInputs)
+	}
+	if diff.RuntimeChange == nil || diff.RuntimeChange.To != "node20" {
+		t.Errorf("DiffActionVersions() runtime change = %+v, want node16 -> node20", diff.RuntimeChange)
+	}
+}
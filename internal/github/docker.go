@@ -0,0 +1,263 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// DockerRefPrefix is the prefix of references to Docker images, e.g. "docker://alpine:3.20".
+// It is used both in "uses:" of workflow steps and in runs.image of Docker actions.
+const DockerRefPrefix = "docker://"
+
+// IsDockerRef reports whether ref references a Docker image, e.g. "docker://alpine:3.20".
+func IsDockerRef(ref string) bool {
+	return strings.HasPrefix(strings.TrimSpace(ref), DockerRefPrefix)
+}
+
+// DockerImage is a parsed reference to a Docker image.
+type DockerImage struct {
+	Registry   string `json:"registry,omitempty" jsonschema:"The registry host, empty for Docker Hub"`
+	Repository string `json:"repository" jsonschema:"The image repository, e.g. alpine or ghcr.io/owner/image without registry"`
+	Tag        string `json:"tag,omitempty" jsonschema:"The image tag, empty if the image is referenced by digest only or uses the implicit latest tag"`
+	Digest     string `json:"digest,omitempty" jsonschema:"The image digest, e.g. sha256:..."`
+}
+
+// String formats the image as "[registry/]repository[:tag][@digest]".
+func (i *DockerImage) String() string {
+	image := i.Repository
+	if i.Registry != "" {
+		image = i.Registry + "/" + image
+	}
+	if i.Tag != "" {
+		image += ":" + i.Tag
+	}
+	if i.Digest != "" {
+		image += "@" + i.Digest
+	}
+	return image
+}
+
+// Version returns the digest or tag identifying the image version. Images without either use the "latest" tag.
+func (i *DockerImage) Version() string {
+	switch {
+	case i.Digest != "":
+		return i.Digest
+	case i.Tag != "":
+		return i.Tag
+	default:
+		return "latest"
+	}
+}
+
+// dockerImagePattern matches "[registry/]repository[:tag][@digest]".
+var dockerImagePattern = regexp.MustCompile(`^([a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*)(?::([\w][\w.-]{0,127}))?(?:@([a-z0-9]+:[a-fA-F0-9]{32,}))?$`)
+
+// ParseDockerImage parses a Docker image reference like "alpine:3.20", "ghcr.io/owner/image@sha256:..."
+// or "docker://alpine:3.20". Malformed references are reported as errors matching ErrInvalidRef.
+//
+// Examples:
+//   - "docker://alpine:3.20" -> {Repository: "alpine", Tag: "3.20"}
+//   - "ghcr.io/owner/image:1" -> {Registry: "ghcr.io", Repository: "owner/image", Tag: "1"}
+func ParseDockerImage(ref string) (*DockerImage, error) {
+	image := strings.TrimPrefix(strings.TrimSpace(ref), DockerRefPrefix)
+	if image == "" {
+		return nil, invalidRef("Docker image reference cannot be empty")
+	}
+
+	// The first path component is a registry if it looks like a host name
+	var registry string
+	if host, rest, ok := strings.Cut(image, "/"); ok && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		registry, image = host, rest
+	}
+
+	match := dockerImagePattern.FindStringSubmatch(image)
+	if match == nil {
+		return nil, invalidRef("invalid Docker image reference '%s'", ref)
+	}
+	return &DockerImage{
+		Registry:   registry,
+		Repository: match[1],
+		Tag:        match[2],
+		Digest:     match[3],
+	}, nil
+}
+
+// DockerAction describes the container a Docker action runs in.
+// The entrypoint, arguments and environment are part of the action's ActionRuns.
+type DockerAction struct {
+	// Image is set if the action runs a prebuilt image ("image: docker://...").
+	Image *DockerImage `json:"image,omitempty" jsonschema:"The prebuilt image the action runs, if runs.image references a registry image"`
+	// Dockerfile is set if the action builds its image from a Dockerfile in the repository.
+	Dockerfile *Dockerfile `json:"dockerfile,omitempty" jsonschema:"The Dockerfile the action's image is built from, if runs.image is a path"`
+}
+
+// Dockerfile summarises a Dockerfile.
+type Dockerfile struct {
+	Path string `json:"path" jsonschema:"The path of the Dockerfile in the repository"`
+	// BaseImage is the image the final stage is based on, following references to earlier stages.
+	BaseImage string            `json:"baseImage" jsonschema:"The image the final stage is built from, following references to earlier build stages"`
+	Stages    []DockerfileStage `json:"stages" jsonschema:"The build stages, in order"`
+	// Entrypoint is the ENTRYPOINT of the final stage as written, e.g. '["/entrypoint.sh"]'.
+	Entrypoint string `json:"entrypoint,omitempty" jsonschema:"The ENTRYPOINT of the final stage as written in the Dockerfile"`
+}
+
+// DockerfileStage is a single FROM instruction of a Dockerfile.
+type DockerfileStage struct {
+	Name  string `json:"name,omitempty" jsonschema:"The stage name given with AS"`
+	Image string `json:"image" jsonschema:"The image or earlier stage the stage is built from"`
+}
+
+// DescribeDockerAction describes the container of a Docker action, given the action reference and the
+// metadata and resolved ref returned by GetActionParameters. Registry images ("docker://...") are parsed;
+// for images built from a Dockerfile, the Dockerfile is fetched from the action's directory at the
+// resolved version, or read from the workspace for local actions, and summarised.
+func (s *ActionsService) DescribeDockerAction(ctx context.Context, actionRef string, resolved *ResolvedRef, runs *ActionRuns) (*DockerAction, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if !runs.IsDocker() {
+		return nil, fmt.Errorf("action runs using '%s', not '%s'", runs.Using, RunsUsingDocker)
+	}
+
+	if IsDockerRef(runs.Image) {
+		image, err := ParseDockerImage(runs.Image)
+		if err != nil {
+			return nil, err
+		}
+		return &DockerAction{Image: image}, nil
+	}
+
+	if IsLocalRef(actionRef) {
+		dir, err := ParseLocalRef(actionRef)
+		if err != nil {
+			return nil, fmt.Errorf("invalid action reference: %w", err)
+		}
+		dockerfilePath := path.Join(dir, runs.Image)
+		data, err := s.ReadLocalFile(ctx, dockerfilePath)
+		if err != nil {
+			return nil, err
+		}
+		dockerfile, err := ParseDockerfile(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", dockerfilePath, err)
+		}
+		dockerfile.Path = dockerfilePath
+		return &DockerAction{Dockerfile: dockerfile}, nil
+	}
+
+	ref, err := ParseActionRef(actionRef)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action reference: %w", err)
+	}
+	dockerfileRef := &Ref{Owner: ref.Owner, Repo: ref.Repo, Path: ref.FilePath(runs.Image), Version: resolved.Version}
+	data, _, err := s.FetchFile(ctx, dockerfileRef)
+	if err != nil {
+		return nil, err
+	}
+	dockerfile, err := ParseDockerfile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", dockerfileRef.Path, err)
+	}
+	dockerfile.Path = dockerfileRef.Path
+	return &DockerAction{Dockerfile: dockerfile}, nil
+}
+
+// ParseDockerfile summarises the build stages of a Dockerfile.
+// Global ARGs declared before the first FROM are substituted with their defaults.
+// The path of the returned Dockerfile is left empty.
+// It returns an error if the Dockerfile cannot be read, e.g. because a line exceeds 64 KiB.
+func ParseDockerfile(data []byte) (*Dockerfile, error) {
+	instructions, err := dockerfileInstructions(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerfile := &Dockerfile{Stages: []DockerfileStage{}}
+	args := map[string]string{}
+	entrypoint := ""
+
+	for _, instruction := range instructions {
+		keyword, rest, _ := strings.Cut(instruction, " ")
+		rest = strings.TrimSpace(rest)
+
+		switch strings.ToUpper(keyword) {
+		case "ARG":
+			// Only ARGs before the first FROM can be used in FROM instructions
+			if len(dockerfile.Stages) > 0 {
+				continue
+			}
+			name, value, _ := strings.Cut(rest, "=")
+			args[name] = strings.Trim(value, `"'`)
+		case "FROM":
+			fields := strings.Fields(rest)
+			// Skip flags such as --platform=linux/amd64
+			for len(fields) > 0 && strings.HasPrefix(fields[0], "--") {
+				fields = fields[1:]
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			stage := DockerfileStage{Image: os.Expand(fields[0], func(name string) string { return args[name] })}
+			if len(fields) >= 3 && strings.EqualFold(fields[1], "AS") {
+				stage.Name = fields[2]
+			}
+			dockerfile.Stages = append(dockerfile.Stages, stage)
+			entrypoint = ""
+		case "ENTRYPOINT":
+			entrypoint = rest
+		}
+	}
+
+	dockerfile.Entrypoint = entrypoint
+	if len(dockerfile.Stages) > 0 {
+		dockerfile.BaseImage = baseImageOfStage(dockerfile.Stages, len(dockerfile.Stages)-1)
+	}
+	return dockerfile, nil
+}
+
+// baseImageOfStage returns the image a stage is built from, following references to earlier stages.
+func baseImageOfStage(stages []DockerfileStage, index int) string {
+	image := stages[index].Image
+	for i := index - 1; i >= 0; i-- {
+		if stages[i].Name != "" && strings.EqualFold(stages[i].Name, image) {
+			return baseImageOfStage(stages, i)
+		}
+	}
+	return image
+}
+
+// dockerfileInstructions splits a Dockerfile into instructions, joining continuation lines
+// and dropping comments and empty lines.
+func dockerfileInstructions(data []byte) ([]string, error) {
+	var instructions []string
+	var current strings.Builder
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") || (line == "" && current.Len() == 0) {
+			continue
+		}
+		if continued, ok := strings.CutSuffix(line, `\`); ok {
+			current.WriteString(continued)
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(line)
+		instructions = append(instructions, strings.Join(strings.Fields(current.String()), " "))
+		current.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Dockerfile: %w", err)
+	}
+	if current.Len() > 0 {
+		instructions = append(instructions, strings.Join(strings.Fields(current.String()), " "))
+	}
+	return instructions, nil
+}
@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDockerImage(t *testing.T) {
+	const digest = "sha256:beefdbd8a1da6d2915566fde36db9db0b524eb737fc57cd1367effd16dc0d06d"
+
+	tests := []struct {
+		name    string
+		input   string
+		want    *DockerImage
+		wantErr bool
+	}{
+		{name: "docker hub image with tag", input: "docker://alpine:3.20", want: &DockerImage{Repository: "alpine", Tag: "3.20"}},
+		{name: "without prefix", input: "alpine", want: &DockerImage{Repository: "alpine"}},
+		{name: "docker hub organization", input: "docker://hashicorp/terraform:1.9", want: &DockerImage{Repository: "hashicorp/terraform", Tag: "1.9"}},
+		{name: "registry", input: "docker://ghcr.io/owner/image:v1", want: &DockerImage{Registry: "ghcr.io", Repository: "owner/image", Tag: "v1"}},
+		{name: "registry with port", input: "docker://localhost:5000/image", want: &DockerImage{Registry: "localhost:5000", Repository: "image"}},
+		{name: "digest", input: "docker://alpine@" + digest, want: &DockerImage{Repository: "alpine", Digest: digest}},
+		{name: "tag and digest", input: "docker://alpine:3.20@" + digest, want: &DockerImage{Repository: "alpine", Tag: "3.20", Digest: digest}},
+		{name: "empty", input: "docker://", wantErr: true},
+		{name: "uppercase repository", input: "docker://Alpine", wantErr: true},
+		{name: "invalid tag", input: "docker://alpine:3.20:1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDockerImage(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRef) {
+					t.Errorf("ParseDockerImage() error = %v, want ErrInvalidRef", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDockerImage() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDockerImage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDockerImage_StringAndVersion(t *testing.T) {
+	image := &DockerImage{Registry: "ghcr.io", Repository: "owner/image", Tag: "v1"}
+	if got := image.String(); got != "ghcr.io/owner/image:v1" {
+		t.Errorf("String() = %q, want %q", got, "ghcr.io/owner/image:v1")
+	}
+	if got := image.Version(); got != "v1" {
+		t.Errorf("Version() = %q, want %q", got, "v1")
+	}
+	if got := (&DockerImage{Repository: "alpine"}).Version(); got != "latest" {
+		t.Errorf("Version() = %q, want %q for an image without tag", got, "latest")
+	}
+}
+
+func TestParseDockerfile(t *testing.T) {
+	data := []byte(`# syntax=docker/dockerfile:1
+ARG GO_VERSION=1.25
+ARG ALPINE="3.20"
+
+FROM --platform=$BUILDPLATFORM golang:${GO_VERSION} AS build
+RUN go build \
+    -o /bin/app .
+ENTRYPOINT ["/bin/build"]
+
+from build as test
+RUN go test ./...
+
+FROM alpine:$ALPINE
+COPY --from=build /bin/app /bin/app
+ENTRYPOINT ["/bin/app"]
+`)
+
+	want := &Dockerfile{
+		BaseImage: "alpine:3.20",
+		Stages: []DockerfileStage{
+			{Name: "build", Image: "golang:1.25"},
+			{Name: "test", Image: "build"},
+			{Image: "alpine:3.20"},
+		},
+		Entrypoint: `["/bin/app"]`,
+	}
+	got, err := ParseDockerfile(data)
+	if err != nil {
+		t.Fatalf("ParseDockerfile() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseDockerfile() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseDockerfile_StageReference(t *testing.T) {
+	data := []byte("FROM node:20-slim AS base\nFROM base\nCMD [\"node\"]\n")
+
+	got, err := ParseDockerfile(data)
+	if err != nil {
+		t.Fatalf("ParseDockerfile() unexpected error: %v", err)
+	}
+	if got.BaseImage != "node:20-slim" {
+		t.Errorf("ParseDockerfile() BaseImage = %q, want the base image of the referenced stage", got.BaseImage)
+	}
+	if got.Entrypoint != "" {
+		t.Errorf("ParseDockerfile() Entrypoint = %q, want none", got.Entrypoint)
+	}
+}
+
+func TestParseDockerfile_LongLine(t *testing.T) {
+	data := []byte("FROM alpine:3.20\nRUN echo " + strings.Repeat("x", 64*1024) + "\nENTRYPOINT [\"/app\"]\n")
+
+	if _, err := ParseDockerfile(data); err == nil {
+		t.Error("ParseDockerfile() expected error for a line longer than 64 KiB but got none")
+	}
+}
+
+func TestGetActionParameters_DockerImage(t *testing.T) {
+	service := NewActionsService(nil)
+
+	metadata, resolved, err := service.GetActionParameters(context.Background(), "docker://alpine:3.20")
+	if err != nil {
+		t.Fatalf("GetActionParameters() unexpected error: %v", err)
+	}
+	if !metadata.Runs.IsDocker() || metadata.Runs.Image != "docker://alpine:3.20" {
+		t.Errorf("GetActionParameters() runs = %+v, want a Docker action running the image", metadata.Runs)
+	}
+	if want := (ResolvedRef{Version: "3.20", Type: RefTypeImage}); *resolved != want {
+		t.Errorf("GetActionParameters() resolved = %+v, want %+v", *resolved, want)
+	}
+
+	docker, err := service.DescribeDockerAction(context.Background(), "docker://alpine:3.20", resolved, &metadata.Runs)
+	if err != nil {
+		t.Fatalf("DescribeDockerAction() unexpected error: %v", err)
+	}
+	if docker.Image == nil || docker.Image.Repository != "alpine" || docker.Dockerfile != nil {
+		t.Errorf("DescribeDockerAction() = %+v, want the parsed image", docker)
+	}
+}
+
+func TestDescribeDockerAction_Dockerfile(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/raw/owner/lint/refs/tags/v2/check/action.yml":
+			fmt.Fprint(w, "name: Lint\nruns:\n  using: docker\n  image: Dockerfile\n  args: ['--strict']\n")
+		case "/raw/owner/lint/refs/tags/v2/check/Dockerfile":
+			fmt.Fprint(w, "FROM python:3.12-slim\nENTRYPOINT [\"/entrypoint.sh\"]\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}), false)
+
+	metadata, resolved, err := service.GetActionParameters(context.Background(), "owner/lint/check@v2")
+	if err != nil {
+		t.Fatalf("GetActionParameters() unexpected error: %v", err)
+	}
+
+	docker, err := service.DescribeDockerAction(context.Background(), "owner/lint/check@v2", resolved, &metadata.Runs)
+	if err != nil {
+		t.Fatalf("DescribeDockerAction() unexpected error: %v", err)
+	}
+	want := &Dockerfile{
+		Path:       "check/Dockerfile",
+		BaseImage:  "python:3.12-slim",
+		Stages:     []DockerfileStage{{Image: "python:3.12-slim"}},
+		Entrypoint: `["/entrypoint.sh"]`,
+	}
+	if docker.Image != nil || !reflect.DeepEqual(docker.Dockerfile, want) {
+		t.Errorf("DescribeDockerAction() = %+v, want Dockerfile %+v", docker, want)
+	}
+}
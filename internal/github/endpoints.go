@@ -0,0 +1,121 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Endpoints holds the base URLs used to talk to a GitHub instance.
+type Endpoints struct {
+	// WebURL is the base URL of the web interface, e.g. "https://github.com".
+	WebURL string
+	// APIURL is the base URL of the REST API, e.g. "https://api.github.com".
+	APIURL string
+	// RawURL is the base URL of the raw content host, e.g. "https://raw.githubusercontent.com".
+	// If empty, files are fetched through the REST API's repository contents endpoint instead,
+	// which is the case for GitHub Enterprise Server.
+	RawURL string
+}
+
+// DefaultEndpoints returns the endpoints of github.com.
+func DefaultEndpoints() *Endpoints {
+	return &Endpoints{
+		WebURL: "https://github.com",
+		APIURL: "https://api.github.com",
+		RawURL: "https://raw.githubusercontent.com",
+	}
+}
+
+// Host returns the host name of the web interface, e.g. "github.com".
+// It is used to look up host-specific credentials.
+func (e *Endpoints) Host() string {
+	parsed, err := url.Parse(e.WebURL)
+	if err != nil || parsed.Host == "" {
+		return DefaultHost
+	}
+	return parsed.Host
+}
+
+// EndpointsForHost derives the endpoints of a GitHub instance from its host name or web URL.
+//   - "github.com" (or an empty host) uses the public github.com endpoints
+//   - "*.ghe.com" (GitHub Enterprise Cloud with data residency) uses "https://api.{host}"
+//   - Any other host is treated as GitHub Enterprise Server and uses "https://{host}/api/v3"
+//
+// Only github.com has a raw content host; all other instances fetch files through the REST API.
+func EndpointsForHost(host string) (*Endpoints, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return DefaultEndpoints(), nil
+	}
+
+	webURL, err := normalizeBaseURL(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub host '%s': %w", host, err)
+	}
+	parsed, _ := url.Parse(webURL)
+	hostname := parsed.Hostname()
+
+	switch {
+	case strings.EqualFold(hostname, DefaultHost):
+		return DefaultEndpoints(), nil
+	case strings.HasSuffix(strings.ToLower(hostname), ".ghe.com"):
+		return &Endpoints{
+			WebURL: webURL,
+			APIURL: fmt.Sprintf("%s://api.%s", parsed.Scheme, parsed.Host),
+		}, nil
+	default:
+		return &Endpoints{
+			WebURL: webURL,
+			APIURL: webURL + "/api/v3",
+		}, nil
+	}
+}
+
+// DiscoverEndpoints determines the GitHub instance to talk to.
+// The host is taken from hostFlag, e.g. the value of a --github-host flag, or from
+// the GITHUB_SERVER_URL environment variable, which is set on GitHub Actions runners.
+// If the host is taken from GITHUB_SERVER_URL and GITHUB_API_URL is set, it overrides the derived
+// REST API URL. GITHUB_API_URL is ignored if hostFlag is given, as runners always set it to the API
+// of the instance running the workflow.
+func DiscoverEndpoints(hostFlag string) (*Endpoints, error) {
+	host := hostFlag
+	if host == "" {
+		host = os.Getenv("GITHUB_SERVER_URL")
+	}
+
+	endpoints, err := EndpointsForHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiURL := os.Getenv("GITHUB_API_URL"); apiURL != "" && hostFlag == "" {
+		normalized, err := normalizeBaseURL(apiURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GITHUB_API_URL '%s': %w", apiURL, err)
+		}
+		endpoints.APIURL = normalized
+	}
+
+	return endpoints, nil
+}
+
+// normalizeBaseURL turns a host name or URL into a base URL without a trailing slash.
+// Host names without a scheme default to https.
+func normalizeBaseURL(value string) (string, error) {
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("unsupported scheme '%s'", parsed.Scheme)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("missing host")
+	}
+	return strings.TrimSuffix(parsed.Scheme+"://"+parsed.Host+parsed.Path, "/"), nil
+}
@@ -0,0 +1,142 @@
+package github
+
+import (
+	"testing"
+)
+
+func TestEndpointsForHost(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		want    Endpoints
+		wantErr bool
+	}{
+		{
+			name: "empty host defaults to github.com",
+			host: "",
+			want: *DefaultEndpoints(),
+		},
+		{
+			name: "github.com",
+			host: "github.com",
+			want: *DefaultEndpoints(),
+		},
+		{
+			name: "github.com URL",
+			host: "https://github.com/",
+			want: *DefaultEndpoints(),
+		},
+		{
+			name: "GitHub Enterprise Server host",
+			host: "ghe.example.com",
+			want: Endpoints{
+				WebURL: "https://ghe.example.com",
+				APIURL: "https://ghe.example.com/api/v3",
+			},
+		},
+		{
+			name: "GitHub Enterprise Server URL with port",
+			host: "http://ghe.example.com:8080/",
+			want: Endpoints{
+				WebURL: "http://ghe.example.com:8080",
+				APIURL: "http://ghe.example.com:8080/api/v3",
+			},
+		},
+		{
+			name: "GitHub Enterprise Cloud with data residency",
+			host: "octocorp.ghe.com",
+			want: Endpoints{
+				WebURL: "https://octocorp.ghe.com",
+				APIURL: "https://api.octocorp.ghe.com",
+			},
+		},
+		{
+			name:    "unsupported scheme",
+			host:    "ftp://ghe.example.com",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EndpointsForHost(tt.host)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("EndpointsForHost() expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("EndpointsForHost() unexpected error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("EndpointsForHost() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverEndpoints(t *testing.T) {
+	t.Run("flag takes precedence over GITHUB_SERVER_URL", func(t *testing.T) {
+		t.Setenv("GITHUB_SERVER_URL", "https://other.example.com")
+		t.Setenv("GITHUB_API_URL", "")
+
+		got, err := DiscoverEndpoints("ghe.example.com")
+		if err != nil {
+			t.Fatalf("DiscoverEndpoints() unexpected error: %v", err)
+		}
+		if got.WebURL != "https://ghe.example.com" {
+			t.Errorf("DiscoverEndpoints() WebURL = %v, want https://ghe.example.com", got.WebURL)
+		}
+	})
+
+	t.Run("flag ignores GITHUB_API_URL", func(t *testing.T) {
+		t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+		t.Setenv("GITHUB_API_URL", "https://api.github.com")
+
+		got, err := DiscoverEndpoints("ghe.example.com")
+		if err != nil {
+			t.Fatalf("DiscoverEndpoints() unexpected error: %v", err)
+		}
+		if got.APIURL != "https://ghe.example.com/api/v3" {
+			t.Errorf("DiscoverEndpoints() APIURL = %v, want https://ghe.example.com/api/v3", got.APIURL)
+		}
+	})
+
+	t.Run("GITHUB_SERVER_URL and GITHUB_API_URL", func(t *testing.T) {
+		t.Setenv("GITHUB_SERVER_URL", "https://ghe.example.com")
+		t.Setenv("GITHUB_API_URL", "https://api.ghe.example.com/")
+
+		got, err := DiscoverEndpoints("")
+		if err != nil {
+			t.Fatalf("DiscoverEndpoints() unexpected error: %v", err)
+		}
+		if got.WebURL != "https://ghe.example.com" {
+			t.Errorf("DiscoverEndpoints() WebURL = %v, want https://ghe.example.com", got.WebURL)
+		}
+		if got.APIURL != "https://api.ghe.example.com" {
+			t.Errorf("DiscoverEndpoints() APIURL = %v, want https://api.ghe.example.com", got.APIURL)
+		}
+		if got.RawURL != "" {
+			t.Errorf("DiscoverEndpoints() RawURL = %v, want empty", got.RawURL)
+		}
+		if got.Host() != "ghe.example.com" {
+			t.Errorf("Endpoints.Host() = %v, want ghe.example.com", got.Host())
+		}
+	})
+
+	t.Run("defaults to github.com", func(t *testing.T) {
+		t.Setenv("GITHUB_SERVER_URL", "")
+		t.Setenv("GITHUB_API_URL", "")
+
+		got, err := DiscoverEndpoints("")
+		if err != nil {
+			t.Fatalf("DiscoverEndpoints() unexpected error: %v", err)
+		}
+		if *got != *DefaultEndpoints() {
+			t.Errorf("DiscoverEndpoints() = %+v, want %+v", *got, *DefaultEndpoints())
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors reported by ActionsService, usable with errors.Is to decide how to react to a failure.
+var (
+	// ErrNotFound indicates that the requested file, ref or repository does not exist,
+	// or is not visible with the configured token.
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized indicates that the token is missing, invalid or lacks access to the resource.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited indicates that a GitHub rate limit was exceeded, even after retrying.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrInvalidRef indicates that a reference could not be parsed.
+	ErrInvalidRef = errors.New("invalid reference")
+	// ErrNoWorkspace indicates that a local reference ("./...") was used, but no workspace
+	// to resolve it against was configured (see WithWorkspace).
+	ErrNoWorkspace = errors.New("no workspace configured to resolve local references")
+)
+
+// HTTPError is returned for requests GitHub answered with an unexpected status code.
+// It unwraps to ErrNotFound, ErrUnauthorized or ErrRateLimited depending on the status code.
+type HTTPError struct {
+	URL        string
+	StatusCode int
+	// RateLimit is the rate limit reported with the response, if any.
+	RateLimit *RateLimit
+}
+
+// newHTTPError creates an HTTPError for a response to url.
+func newHTTPError(url string, resp *response) *HTTPError {
+	httpErr := &HTTPError{URL: url, StatusCode: resp.StatusCode}
+	if rateLimit, ok := parseRateLimit(resp.Header); ok {
+		httpErr.RateLimit = &rateLimit
+	}
+	return httpErr
+}
+
+// Error implements error.
+func (e *HTTPError) Error() string {
+	if kind := e.Unwrap(); kind != nil {
+		return fmt.Sprintf("%s at %s (status: %d)", kind, e.URL, e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected response from %s (status: %d)", e.URL, e.StatusCode)
+}
+
+// Unwrap returns the sentinel error matching the status code, or nil if there is none.
+// GitHub uses 403 both for exhausted rate limits and for tokens lacking permissions,
+// which are told apart by the remaining rate limit.
+func (e *HTTPError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusForbidden:
+		if e.RateLimit != nil && e.RateLimit.Remaining == 0 {
+			return ErrRateLimited
+		}
+		return ErrUnauthorized
+	default:
+		return nil
+	}
+}
+
+// invalidRefError is returned by ParseRef for malformed references. It unwraps to ErrInvalidRef.
+type invalidRefError struct {
+	msg string
+}
+
+// invalidRef creates an invalidRefError with a formatted message.
+func invalidRef(format string, args ...any) error {
+	return &invalidRefError{msg: fmt.Sprintf(format, args...)}
+}
+
+// Error implements error.
+func (e *invalidRefError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns ErrInvalidRef.
+func (e *invalidRefError) Unwrap() error {
+	return ErrInvalidRef
+}
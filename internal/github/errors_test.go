@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestHTTPError_Is(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		rateLimit  *RateLimit
+		want       error
+	}{
+		{name: "not found", statusCode: http.StatusNotFound, want: ErrNotFound},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, want: ErrUnauthorized},
+		{name: "forbidden", statusCode: http.StatusForbidden, rateLimit: &RateLimit{Limit: 5000, Remaining: 4999}, want: ErrUnauthorized},
+		{name: "forbidden without rate limit", statusCode: http.StatusForbidden, want: ErrUnauthorized},
+		{name: "forbidden with exhausted rate limit", statusCode: http.StatusForbidden, rateLimit: &RateLimit{Limit: 60, Remaining: 0}, want: ErrRateLimited},
+		{name: "too many requests", statusCode: http.StatusTooManyRequests, want: ErrRateLimited},
+		{name: "server error", statusCode: http.StatusBadGateway, want: nil},
+	}
+
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrRateLimited, ErrInvalidRef}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := error(&HTTPError{URL: "https://example.com", StatusCode: tt.statusCode, RateLimit: tt.rateLimit})
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v, want %v", err, sentinel, got, !got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRef_InvalidRefError(t *testing.T) {
+	for _, ref := range []string{"", "actions", "actions/checkout", "actions/checkout@v1@v2", "actions/checkout/../init@v5"} {
+		_, err := ParseActionRef(ref)
+		if !errors.Is(err, ErrInvalidRef) {
+			t.Errorf("ParseActionRef(%q) error = %v, want it to match ErrInvalidRef", ref, err)
+		}
+	}
+}
+
+func TestGetActionParameters_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		actionRef    string
+		want         error
+		wantRequests int
+	}{
+		{
+			name:         "not found",
+			handler:      func(w http.ResponseWriter, r *http.Request) { http.NotFound(w, r) },
+			actionRef:    "actions/checkout@v5",
+			want:         ErrNotFound,
+			wantRequests: 4,
+		},
+		{
+			name: "rate limited",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Limit", "60")
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.WriteHeader(http.StatusForbidden)
+			},
+			actionRef:    "actions/checkout@v5",
+			want:         ErrRateLimited,
+			wantRequests: 1,
+		},
+		{
+			name:         "unauthorized",
+			handler:      func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusUnauthorized) },
+			actionRef:    "actions/checkout@v5",
+			want:         ErrUnauthorized,
+			wantRequests: 1,
+		},
+		{
+			name:      "invalid ref",
+			handler:   func(w http.ResponseWriter, r *http.Request) { http.NotFound(w, r) },
+			actionRef: "actions/checkout",
+			want:      ErrInvalidRef,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				tt.handler(w, r)
+			}), true)
+			// Rate-limited requests would otherwise be retried after a backoff
+			service.httpClient.Transport.(*retryTransport).maxRetries = 0
+
+			_, _, err := service.GetActionParameters(context.Background(), tt.actionRef)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("GetActionParameters() error = %v, want it to match %v", err, tt.want)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("Server received %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// maxResponseSize is the largest response body read from GitHub, which bounds the memory
+// used for a single file. GitHub itself serves files of at most 100 MB.
+const maxResponseSize = 100 << 20
+
+// response is the result of a GET request sent to GitHub.
+type response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// withTimeout derives a context from ctx which is cancelled after the service's overall timeout.
+// Public operations wrap their context with it, so that they finish in bounded time
+// even if the caller's context has no deadline.
+func (s *ActionsService) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, s.timeout)
+}
+
+// newRequest creates a GET request for url which is authenticated with the
+// configured token, if any, and cancelled together with ctx.
+// All requests to GitHub must be created with it.
+func (s *ActionsService) newRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return req, nil
+}
+
+// get sends req and reads the full response body.
+//
+// If a cache is configured, successful responses are served from and stored in it.
+// Immutable responses (e.g. files at a full commit SHA) are never revalidated,
+// while stale responses are revalidated with If-None-Match using the stored ETag.
+// Non-200 responses are returned as is and never cached.
+func (s *ActionsService) get(req *http.Request, immutable bool) (*response, error) {
+	url := req.URL.String()
+	key := newCacheKey(req)
+
+	var cached *cacheEntry
+	if s.cache != nil {
+		if entry, ok := s.cache.get(key); ok {
+			if s.cache.isFresh(entry) {
+				return &response{StatusCode: http.StatusOK, Body: entry.Body}, nil
+			}
+			cached = entry
+			if cached.ETag != "" {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	s.rateLimit.update(resp.Header)
+
+	// The cached entry is still valid, so only its timestamp needs to be refreshed
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		// Failing to update the cache must not fail the request
+		_ = s.cache.touch(cached)
+		return &response{StatusCode: http.StatusOK, Header: resp.Header, Body: cached.Body}, nil
+	}
+
+	body, err := readAllBody(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(body) > maxResponseSize {
+		return nil, fmt.Errorf("response from %s exceeds %d bytes", url, maxResponseSize)
+	}
+
+	if resp.StatusCode == http.StatusOK && s.cache != nil {
+		_ = s.cache.touch(&cacheEntry{
+			URL:        key.URL,
+			Accept:     key.Accept,
+			Credential: key.Credential,
+			ETag:       resp.Header.Get("ETag"),
+			Immutable:  immutable,
+			Body:       body,
+		})
+	}
+
+	return &response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
+}
+
+// tracingTransport records every outgoing request as a Sentry span.
+// Spans are only created as children of a span found in the request's context,
+// e.g. the transaction started by the MCP server for a tool call, so requests
+// made outside of a traced operation do not start transactions of their own.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if sentry.SpanFromContext(req.Context()) == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	span := sentry.StartSpan(req.Context(), "http.client",
+		sentry.WithDescription(fmt.Sprintf("%s %s", req.Method, req.URL.Redacted())),
+	)
+	defer span.Finish()
+
+	span.SetData("http.request.method", req.Method)
+	span.SetData("url", req.URL.Redacted())
+	span.SetData("server.address", req.URL.Hostname())
+
+	resp, err := t.base.RoundTrip(req.WithContext(span.Context()))
+	if err != nil {
+		span.Status = sentry.SpanStatusInternalError
+		return nil, err
+	}
+
+	span.SetData("http.response.status_code", resp.StatusCode)
+	span.Status = sentry.HTTPtoSpanStatus(resp.StatusCode)
+	return resp, nil
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchRawFile_RequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	service := NewActionsService(&ActionsServiceOptions{
+		Endpoints:      &Endpoints{WebURL: server.URL, APIURL: server.URL, RawURL: server.URL},
+		RequestTimeout: 50 * time.Millisecond,
+		// Timed out attempts would otherwise be retried
+		MaxRetries: -1,
+	})
+
+	start := time.Now()
+	_, err := service.FetchRawFile(context.Background(), "actions", "checkout", "refs/tags/v5", "action.yml")
+	if err == nil {
+		t.Fatalf("FetchRawFile() expected timeout error but got none")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("FetchRawFile() took %v, want it to time out after about 50ms", elapsed)
+	}
+}
+
+func TestGetActionParameters_ContextCancellation(t *testing.T) {
+	requests := 0
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}), false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := service.GetActionParameters(ctx, "actions/checkout@v5")
+	if err == nil {
+		t.Fatalf("GetActionParameters() expected error for cancelled context but got none")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetActionParameters() error = %v, want it to wrap context.Canceled", err)
+	}
+	if requests != 0 {
+		t.Errorf("Server received %d requests, want none for a cancelled context", requests)
+	}
+}
+
+func TestGetActionParameters_OverallTimeout(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		http.NotFound(w, r)
+	}), false)
+	service.timeout = 40 * time.Millisecond
+
+	_, _, err := service.GetActionParameters(context.Background(), "actions/checkout@v5")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetActionParameters() error = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalRefPrefix is the prefix of references to actions and reusable workflows in the repository
+// of the workflow itself, e.g. "./.github/actions/setup". They are resolved against the root of the workspace.
+const LocalRefPrefix = "./"
+
+// IsLocalRef reports whether ref references an action or workflow in the workspace, e.g. "./.github/actions/setup".
+func IsLocalRef(ref string) bool {
+	return strings.HasPrefix(strings.TrimSpace(ref), LocalRefPrefix)
+}
+
+// ParseLocalRef parses a local reference like "./.github/actions/setup" and returns the
+// slash-separated path it points to, relative to the root of the workspace ("." for "./").
+// References which are not local or navigate outside of the workspace are reported as errors
+// matching ErrInvalidRef.
+//
+// Examples:
+//   - "./.github/actions/setup" -> ".github/actions/setup"
+//   - "./" -> "."
+func ParseLocalRef(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if !IsLocalRef(ref) {
+		return "", invalidRef("invalid local reference: expected './path', got '%s'", ref)
+	}
+	if strings.Contains(ref, `\`) {
+		return "", invalidRef("invalid local reference '%s': paths must use forward slashes", ref)
+	}
+
+	localPath := path.Clean(strings.TrimPrefix(ref, LocalRefPrefix))
+	if localPath == ".." || strings.HasPrefix(localPath, "../") {
+		return "", invalidRef("invalid local reference '%s': path must not navigate outside of the workspace", ref)
+	}
+	return localPath, nil
+}
+
+// workspaceKey is the context key of the workspace set by WithWorkspace.
+type workspaceKey struct{}
+
+// WithWorkspace returns a copy of ctx in which local references are resolved against dir
+// instead of the workspace the service was configured with (see ActionsServiceOptions.Workspace).
+func WithWorkspace(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, dir)
+}
+
+// Workspace returns the directory local references are resolved against: the one set on ctx
+// with WithWorkspace, or else the one the service was configured with.
+// It returns an empty string if local references cannot be resolved.
+func (s *ActionsService) Workspace(ctx context.Context) string {
+	if dir, ok := ctx.Value(workspaceKey{}).(string); ok && dir != "" {
+		return dir
+	}
+	return s.workspace
+}
+
+// ReadLocalFile reads the file at the slash-separated path relative to the root of the workspace
+// (see Workspace). Paths cannot escape the workspace, neither with ".." nor through symbolic links.
+// Missing files are reported as errors matching ErrNotFound, and a missing workspace as ErrNoWorkspace.
+func (s *ActionsService) ReadLocalFile(ctx context.Context, name string) ([]byte, error) {
+	workspace := s.Workspace(ctx)
+	if workspace == "" {
+		return nil, fmt.Errorf("cannot read ./%s: %w", name, ErrNoWorkspace)
+	}
+
+	root, err := os.OpenRoot(workspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workspace: %w", err)
+	}
+	defer root.Close()
+
+	data, err := root.ReadFile(filepath.FromSlash(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("./%s does not exist in the workspace: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ./%s: %w", name, err)
+	}
+	return data, nil
+}
+
+// FetchLocalActionYAML reads the action.yml or action.yaml file of a local action
+// (e.g. "./.github/actions/setup") from the workspace (see ReadLocalFile).
+// The returned ResolvedRef has type RefTypeLocal and the action's path as version.
+func (s *ActionsService) FetchLocalActionYAML(ctx context.Context, actionRef string) ([]byte, *ResolvedRef, error) {
+	dir, err := ParseLocalRef(actionRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid action reference: %w", err)
+	}
+
+	var lastErr error
+	for _, filename := range []string{"action.yml", "action.yaml"} {
+		data, err := s.ReadLocalFile(ctx, path.Join(dir, filename))
+		if err == nil {
+			return data, &ResolvedRef{Version: dir, Type: RefTypeLocal}, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, nil, err
+		}
+		lastErr = err
+	}
+	return nil, nil, fmt.Errorf("action.yml or action.yaml not found for %s: %w", actionRef, lastErr)
+}
@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseLocalRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "action directory", input: "./.github/actions/setup", want: ".github/actions/setup"},
+		{name: "repository root", input: "./", want: "."},
+		{name: "trailing slash", input: "./.github/actions/setup/", want: ".github/actions/setup"},
+		{name: "whitespace", input: "  ./action  ", want: "action"},
+		{name: "parent within workspace", input: "./a/../b", want: "b"},
+		{name: "parent directory", input: "./../other", wantErr: true},
+		{name: "escapes after cleaning", input: "./a/../../other", wantErr: true},
+		{name: "backslashes", input: `./a\..\..\other`, wantErr: true},
+		{name: "not local", input: "actions/checkout@v5", wantErr: true},
+		{name: "absolute path", input: "/etc/passwd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLocalRef(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRef) {
+					t.Errorf("ParseLocalRef(%q) error = %v, want ErrInvalidRef", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLocalRef(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLocalRef(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestWorkspace creates a workspace directory containing the given files.
+func newTestWorkspace(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGetActionParameters_LocalAction(t *testing.T) {
+	workspace := newTestWorkspace(t, map[string]string{
+		".github/actions/setup/action.yaml": "name: Setup\ninputs:\n  version:\n    required: true\nruns:\n  using: composite\n  steps: []\n",
+	})
+	service := NewActionsService(&ActionsServiceOptions{Workspace: workspace})
+
+	metadata, resolved, err := service.GetActionParameters(context.Background(), "./.github/actions/setup")
+	if err != nil {
+		t.Fatalf("GetActionParameters() unexpected error: %v", err)
+	}
+	if metadata.Name != "Setup" || !metadata.Inputs["version"].Required {
+		t.Errorf("GetActionParameters() metadata = %+v, want the local action.yaml", metadata)
+	}
+	if want := (ResolvedRef{Version: ".github/actions/setup", Type: RefTypeLocal}); *resolved != want {
+		t.Errorf("GetActionParameters() resolved = %+v, want %+v", resolved, want)
+	}
+}
+
+func TestGetActionParameters_LocalActionErrors(t *testing.T) {
+	workspace := newTestWorkspace(t, map[string]string{"action.yml": "name: Root\n"})
+	outside := newTestWorkspace(t, map[string]string{"action.yml": "name: Outside\n"})
+	if err := os.Symlink(outside, filepath.Join(workspace, "link")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		workspace string
+		actionRef string
+		wantErr   error
+	}{
+		{name: "no workspace", actionRef: "./", wantErr: ErrNoWorkspace},
+		{name: "missing action", workspace: workspace, actionRef: "./missing", wantErr: ErrNotFound},
+		{name: "parent directory", workspace: workspace, actionRef: "./../x", wantErr: ErrInvalidRef},
+		{name: "symbolic link out of the workspace", workspace: workspace, actionRef: "./link"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewActionsService(&ActionsServiceOptions{Workspace: tt.workspace})
+
+			metadata, _, err := service.GetActionParameters(context.Background(), tt.actionRef)
+			if err == nil {
+				t.Fatalf("GetActionParameters() = %+v, want an error", metadata)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetActionParameters() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithWorkspace(t *testing.T) {
+	configured := newTestWorkspace(t, map[string]string{"action.yml": "name: Configured\n"})
+	override := newTestWorkspace(t, map[string]string{"action.yml": "name: Override\n"})
+	service := NewActionsService(&ActionsServiceOptions{Workspace: configured})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "configured workspace", ctx: context.Background(), want: "Configured"},
+		{name: "workspace from context", ctx: WithWorkspace(context.Background(), override), want: "Override"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, _, err := service.GetActionParameters(tt.ctx, "./")
+			if err != nil {
+				t.Fatalf("GetActionParameters() unexpected error: %v", err)
+			}
+			if metadata.Name != tt.want {
+				t.Errorf("GetActionParameters() Name = %q, want %q", metadata.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetWorkflowYAML_LocalWorkflow(t *testing.T) {
+	workspace := newTestWorkspace(t, map[string]string{".github/workflows/build.yml": "on: workflow_call\n"})
+	service := NewActionsService(&ActionsServiceOptions{Workspace: workspace})
+
+	data, resolved, err := service.GetWorkflowYAML(context.Background(), "./.github/workflows/build.yml")
+	if err != nil {
+		t.Fatalf("GetWorkflowYAML() unexpected error: %v", err)
+	}
+	if string(data) != "on: workflow_call\n" || resolved.Type != RefTypeLocal {
+		t.Errorf("GetWorkflowYAML() = %q, %+v, want the local workflow", data, resolved)
+	}
+
+	if _, _, err := service.GetWorkflowYAML(context.Background(), "./.github/actions/setup/action.yml"); !errors.Is(err, ErrInvalidRef) {
+		t.Errorf("GetWorkflowYAML() error = %v, want ErrInvalidRef for a file outside of %s", err, WorkflowsDir)
+	}
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ActionMetadata is the typed representation of an action.yml file.
+// See: https://docs.github.com/en/actions/reference/workflows-and-actions/metadata-syntax
+//
+// The JSON field names mirror the keys used in action.yml, so the JSON encoding
+// of this struct has the same shape as the original YAML document.
+type ActionMetadata struct {
+	Name        string                  `yaml:"name" json:"name" jsonschema:"The name of the action"`
+	Author      string                  `yaml:"author" json:"author,omitempty" jsonschema:"The name of the action's author"`
+	Description string                  `yaml:"description" json:"description" jsonschema:"A short description of the action"`
+	Branding    *ActionBranding         `yaml:"branding" json:"branding,omitempty" jsonschema:"Icon and color used to represent the action in GitHub Marketplace"`
+	Inputs      map[string]ActionInput  `yaml:"inputs" json:"inputs,omitempty" jsonschema:"Input parameters accepted by the action, keyed by input ID"`
+	Outputs     map[string]ActionOutput `yaml:"outputs" json:"outputs,omitempty" jsonschema:"Output parameters set by the action, keyed by output ID"`
+	Runs        ActionRuns              `yaml:"runs" json:"runs" jsonschema:"How the action is executed"`
+}
+
+// ActionBranding configures how an action is displayed in GitHub Marketplace.
+type ActionBranding struct {
+	Icon  string `yaml:"icon" json:"icon,omitempty"`
+	Color string `yaml:"color" json:"color,omitempty"`
+}
+
+// ActionInput describes a single input parameter of an action.
+type ActionInput struct {
+	Description        string `yaml:"description" json:"description,omitempty"`
+	Required           bool   `yaml:"required" json:"required"`
+	Default            string `yaml:"default" json:"default,omitempty"`
+	DeprecationMessage string `yaml:"deprecationMessage" json:"deprecationMessage,omitempty"`
+}
+
+// UnmarshalYAML decodes an input, accepting both booleans and quoted strings
+// (e.g. required: 'false') for the required field, as both are found in the wild.
+func (i *ActionInput) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		Description        string `yaml:"description"`
+		Required           string `yaml:"required"`
+		Default            string `yaml:"default"`
+		DeprecationMessage string `yaml:"deprecationMessage"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	required := false
+	if raw.Required != "" {
+		parsed, err := strconv.ParseBool(raw.Required)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid value for required: %q", value.Line, raw.Required)
+		}
+		required = parsed
+	}
+
+	*i = ActionInput{
+		Description:        raw.Description,
+		Required:           required,
+		Default:            raw.Default,
+		DeprecationMessage: raw.DeprecationMessage,
+	}
+	return nil
+}
+
+// ActionOutput describes a single output parameter of an action.
+// The value is only set for composite actions, where it maps to a step output.
+type ActionOutput struct {
+	Description string `yaml:"description" json:"description,omitempty"`
+	Value       string `yaml:"value" json:"value,omitempty"`
+}
+
+// ActionRuns describes how an action is executed.
+// Which fields are set depends on the runtime declared in Using:
+//   - JavaScript actions ("node20", "node24", ...): Main, Pre, PreIf, Post, PostIf
+//   - Docker actions ("docker"): Image, Entrypoint, PreEntrypoint, PostEntrypoint, Args, Env
+//   - Composite actions ("composite"): Steps
+type ActionRuns struct {
+	Using string `yaml:"using" json:"using" jsonschema:"The runtime used to execute the action, e.g. node20, docker or composite"`
+
+	// JavaScript actions
+	Main   string `yaml:"main" json:"main,omitempty"`
+	Pre    string `yaml:"pre" json:"pre,omitempty"`
+	PreIf  string `yaml:"pre-if" json:"pre-if,omitempty"`
+	Post   string `yaml:"post" json:"post,omitempty"`
+	PostIf string `yaml:"post-if" json:"post-if,omitempty"`
+
+	// Docker actions
+	Image          string            `yaml:"image" json:"image,omitempty"`
+	PreEntrypoint  string            `yaml:"pre-entrypoint" json:"pre-entrypoint,omitempty"`
+	Entrypoint     string            `yaml:"entrypoint" json:"entrypoint,omitempty"`
+	PostEntrypoint string            `yaml:"post-entrypoint" json:"post-entrypoint,omitempty"`
+	Args           []string          `yaml:"args" json:"args,omitempty"`
+	Env            map[string]string `yaml:"env" json:"env,omitempty"`
+
+	// Composite actions
+	Steps []CompositeStep `yaml:"steps" json:"steps,omitempty"`
+}
+
+// Runtime constants for ActionRuns.Using that are not JavaScript runtimes.
+const (
+	RunsUsingDocker    = "docker"
+	RunsUsingComposite = "composite"
+)
+
+// IsJavaScript reports whether the action runs on a Node.js runtime (e.g. "node20").
+func (r *ActionRuns) IsJavaScript() bool {
+	return strings.HasPrefix(r.Using, "node")
+}
+
+// IsDocker reports whether the action runs in a Docker container.
+func (r *ActionRuns) IsDocker() bool {
+	return r.Using == RunsUsingDocker
+}
+
+// IsComposite reports whether the action is a composite action.
+func (r *ActionRuns) IsComposite() bool {
+	return r.Using == RunsUsingComposite
+}
+
+// CompositeStep is a single step of a composite action.
+type CompositeStep struct {
+	ID               string            `yaml:"id" json:"id,omitempty"`
+	Name             string            `yaml:"name" json:"name,omitempty"`
+	If               string            `yaml:"if" json:"if,omitempty"`
+	Uses             string            `yaml:"uses" json:"uses,omitempty"`
+	With             map[string]string `yaml:"with" json:"with,omitempty"`
+	Run              string            `yaml:"run" json:"run,omitempty"`
+	Shell            string            `yaml:"shell" json:"shell,omitempty"`
+	Env              map[string]string `yaml:"env" json:"env,omitempty"`
+	WorkingDirectory string            `yaml:"working-directory" json:"working-directory,omitempty"`
+	ContinueOnError  string            `yaml:"continue-on-error" json:"continue-on-error,omitempty"`
+}
@@ -0,0 +1,209 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseActionYAML_JavaScriptAction(t *testing.T) {
+	data := []byte(`
+name: 'Checkout'
+author: 'GitHub'
+description: 'Checkout a Git repository at a particular version'
+branding:
+  icon: 'git-branch'
+  color: 'gray-dark'
+inputs:
+  repository:
+    description: 'Repository name with owner.'
+    default: ${{ github.repository }}
+  fetch-depth:
+    description: 'Number of commits to fetch.'
+    default: 1
+  token:
+    description: 'Personal access token'
+    required: true
+  lfs:
+    description: 'Whether to download Git-LFS files'
+    required: 'false'
+  old-input:
+    description: 'An old input'
+    deprecationMessage: 'Use new-input instead'
+outputs:
+  ref:
+    description: 'The branch, tag or SHA that was checked out'
+runs:
+  using: node20
+  main: dist/index.js
+  post: dist/index.js
+  post-if: success()
+`)
+
+	metadata, err := ParseActionYAML(data)
+	if err != nil {
+		t.Fatalf("ParseActionYAML() unexpected error: %v", err)
+	}
+
+	if metadata.Name != "Checkout" {
+		t.Errorf("Name = %q, want %q", metadata.Name, "Checkout")
+	}
+	if metadata.Author != "GitHub" {
+		t.Errorf("Author = %q, want %q", metadata.Author, "GitHub")
+	}
+	if metadata.Branding == nil || metadata.Branding.Icon != "git-branch" || metadata.Branding.Color != "gray-dark" {
+		t.Errorf("Branding = %+v, want icon git-branch and color gray-dark", metadata.Branding)
+	}
+	if len(metadata.Inputs) != 5 {
+		t.Errorf("len(Inputs) = %d, want 5", len(metadata.Inputs))
+	}
+	if got := metadata.Inputs["fetch-depth"].Default; got != "1" {
+		t.Errorf("Inputs[fetch-depth].Default = %q, want %q", got, "1")
+	}
+	if !metadata.Inputs["token"].Required {
+		t.Errorf("Inputs[token].Required = false, want true")
+	}
+	if metadata.Inputs["lfs"].Required {
+		t.Errorf("Inputs[lfs].Required = true, want false")
+	}
+	if got := metadata.Inputs["old-input"].DeprecationMessage; got != "Use new-input instead" {
+		t.Errorf("Inputs[old-input].DeprecationMessage = %q, want %q", got, "Use new-input instead")
+	}
+	if got := metadata.Outputs["ref"].Description; got != "The branch, tag or SHA that was checked out" {
+		t.Errorf("Outputs[ref].Description = %q", got)
+	}
+	if !metadata.Runs.IsJavaScript() {
+		t.Errorf("Runs.IsJavaScript() = false, want true for %q", metadata.Runs.Using)
+	}
+	if metadata.Runs.Main != "dist/index.js" || metadata.Runs.PostIf != "success()" {
+		t.Errorf("Runs = %+v, want main dist/index.js and post-if success()", metadata.Runs)
+	}
+}
+
+func TestParseActionYAML_DockerAction(t *testing.T) {
+	data := []byte(`
+name: 'Hello World'
+description: 'Greet someone'
+runs:
+  using: 'docker'
+  image: 'Dockerfile'
+  entrypoint: '/entrypoint.sh'
+  args:
+    - ${{ inputs.who-to-greet }}
+    - 42
+  env:
+    GREETING: hello
+`)
+
+	metadata, err := ParseActionYAML(data)
+	if err != nil {
+		t.Fatalf("ParseActionYAML() unexpected error: %v", err)
+	}
+
+	if !metadata.Runs.IsDocker() {
+		t.Errorf("Runs.IsDocker() = false, want true")
+	}
+	if metadata.Runs.Image != "Dockerfile" {
+		t.Errorf("Runs.Image = %q, want %q", metadata.Runs.Image, "Dockerfile")
+	}
+	if len(metadata.Runs.Args) != 2 || metadata.Runs.Args[1] != "42" {
+		t.Errorf("Runs.Args = %v, want 2 args with 42 as second", metadata.Runs.Args)
+	}
+	if metadata.Runs.Env["GREETING"] != "hello" {
+		t.Errorf("Runs.Env = %v, want GREETING=hello", metadata.Runs.Env)
+	}
+}
+
+func TestParseActionYAML_CompositeAction(t *testing.T) {
+	data := []byte(`
+name: 'Setup'
+description: 'Set up the toolchain'
+outputs:
+  cache-hit:
+    description: 'Whether the cache was hit'
+    value: ${{ steps.cache.outputs.cache-hit }}
+runs:
+  using: composite
+  steps:
+    - id: cache
+      uses: actions/cache@v4
+      with:
+        path: ~/.cache
+        lookup-only: true
+    - name: Install
+      run: make install
+      shell: bash
+      continue-on-error: true
+      working-directory: ./tools
+`)
+
+	metadata, err := ParseActionYAML(data)
+	if err != nil {
+		t.Fatalf("ParseActionYAML() unexpected error: %v", err)
+	}
+
+	if !metadata.Runs.IsComposite() {
+		t.Fatalf("Runs.IsComposite() = false, want true")
+	}
+	if len(metadata.Runs.Steps) != 2 {
+		t.Fatalf("len(Runs.Steps) = %d, want 2", len(metadata.Runs.Steps))
+	}
+	first := metadata.Runs.Steps[0]
+	if first.Uses != "actions/cache@v4" || first.With["lookup-only"] != "true" {
+		t.Errorf("Runs.Steps[0] = %+v, want uses actions/cache@v4 with lookup-only=true", first)
+	}
+	second := metadata.Runs.Steps[1]
+	if second.Shell != "bash" || second.ContinueOnError != "true" || second.WorkingDirectory != "./tools" {
+		t.Errorf("Runs.Steps[1] = %+v", second)
+	}
+	if got := metadata.Outputs["cache-hit"].Value; got != "${{ steps.cache.outputs.cache-hit }}" {
+		t.Errorf("Outputs[cache-hit].Value = %q", got)
+	}
+}
+
+func TestParseActionYAML_InvalidRequired(t *testing.T) {
+	data := []byte(`
+name: 'Broken'
+inputs:
+  foo:
+    required: maybe
+runs:
+  using: node20
+  main: index.js
+`)
+
+	if _, err := ParseActionYAML(data); err == nil {
+		t.Errorf("ParseActionYAML() expected error for invalid required value but got none")
+	}
+}
+
+func TestActionMetadata_JSONKeys(t *testing.T) {
+	metadata := ActionMetadata{
+		Name: "Test",
+		Inputs: map[string]ActionInput{
+			"foo": {Description: "Foo", DeprecationMessage: "Deprecated"},
+		},
+		Runs: ActionRuns{Using: "node20", Main: "index.js", PostIf: "always()"},
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() unexpected error: %v", err)
+	}
+
+	runs := decoded["runs"].(map[string]any)
+	if runs["post-if"] != "always()" {
+		t.Errorf("runs[post-if] = %v, want always()", runs["post-if"])
+	}
+	input := decoded["inputs"].(map[string]any)["foo"].(map[string]any)
+	if input["deprecationMessage"] != "Deprecated" {
+		t.Errorf("inputs.foo.deprecationMessage = %v, want Deprecated", input["deprecationMessage"])
+	}
+	if _, ok := decoded["outputs"]; ok {
+		t.Errorf("outputs should be omitted when empty")
+	}
+}
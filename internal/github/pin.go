@@ -0,0 +1,318 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Statuses of a PinnedAction.
+const (
+	PinStatusPinned        = "pinned"
+	PinStatusAlreadyPinned = "already_pinned"
+	PinStatusSkipped       = "skipped"
+	PinStatusFailed        = "failed"
+)
+
+// PinnedAction reports what happened to a single "uses:" reference when pinning a workflow.
+type PinnedAction struct {
+	Line     int    `json:"line" jsonschema:"The line of the uses: reference in the workflow"`
+	Original string `json:"original" jsonschema:"The original reference, e.g. actions/checkout@v4"`
+	Pinned   string `json:"pinned,omitempty" jsonschema:"The reference pinned to a full commit SHA"`
+	Version  string `json:"version,omitempty" jsonschema:"The tag or branch the SHA was resolved from, added as comment"`
+	Status   string `json:"status" jsonschema:"One of pinned, already_pinned, skipped or failed"`
+	Reason   string `json:"reason,omitempty" jsonschema:"Why the reference was skipped or could not be pinned"`
+}
+
+// PinResult is the result of pinning the actions of a workflow.
+type PinResult struct {
+	Content string         `json:"content" jsonschema:"The workflow with all resolvable references pinned"`
+	Diff    string         `json:"diff" jsonschema:"A unified diff of the changes, empty if nothing changed"`
+	Actions []PinnedAction `json:"actions" jsonschema:"Every uses: reference found in the workflow, in order of appearance"`
+}
+
+// Changed reports whether pinning changed the workflow.
+func (r *PinResult) Changed() bool {
+	return r.Diff != ""
+}
+
+// PinWorkflow pins every action and reusable workflow referenced with "uses:" in a workflow
+// (or composite action) to a full commit SHA, e.g. "actions/checkout@v4" becomes
+// "actions/checkout@<sha> # v4.2.1".
+//
+// Versions are resolved through the repository's tags (see ResolveActionVersion), so moving tags
+// such as "v4" are annotated with the exact release. Branches are resolved to their head commit.
+// Local actions ("./..."), Docker images ("docker://...") and references already pinned to a
+// full SHA are left unchanged. Only the reference and its trailing comment are rewritten, so the
+// formatting and all other comments of the workflow are preserved. Existing trailing comments
+// which are not a version are kept after the new version comment.
+//
+// References which cannot be resolved are reported as failed and left unchanged;
+// name is only used in the diff header.
+func (s *ActionsService) PinWorkflow(ctx context.Context, name string, content []byte) (*PinResult, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	pinned := make([]string, len(lines))
+	copy(pinned, lines)
+
+	resolver := &shaResolver{service: s, tags: map[string][]Tag{}, shas: map[string]*pinTarget{}}
+	actions := []PinnedAction{}
+	for _, node := range findUsesNodes(&document) {
+		action := PinnedAction{Line: node.Line, Original: node.Value}
+
+		target, reason, err := resolver.resolve(ctx, node.Value)
+		switch {
+		case err != nil:
+			// Stop once the operation was cancelled or timed out, as all further references would fail as well
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			action.Status = PinStatusFailed
+			action.Reason = err.Error()
+		case target == nil:
+			action.Status = reason
+			if reason == PinStatusSkipped {
+				action.Reason = "local actions and Docker images cannot be pinned to a commit SHA"
+			}
+		default:
+			line, ok := rewriteUsesLine(pinned[node.Line-1], node, target.Ref, target.Version)
+			if !ok {
+				action.Status = PinStatusFailed
+				action.Reason = "could not locate the reference in the workflow"
+				break
+			}
+			pinned[node.Line-1] = line
+			action.Status = PinStatusPinned
+			action.Pinned = target.Ref
+			action.Version = target.Version
+		}
+		actions = append(actions, action)
+	}
+
+	return &PinResult{
+		Content: strings.Join(pinned, "\n"),
+		Diff:    unifiedDiff(name, lines, pinned),
+		Actions: actions,
+	}, nil
+}
+
+// findUsesNodes returns the scalar values of all "uses" keys in a YAML document, in document order.
+// This covers steps of jobs and composite actions as well as jobs calling reusable workflows.
+func findUsesNodes(node *yaml.Node) []*yaml.Node {
+	var nodes []*yaml.Node
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "uses" && value.Kind == yaml.ScalarNode {
+				nodes = append(nodes, value)
+				continue
+			}
+			nodes = append(nodes, findUsesNodes(value)...)
+		}
+		return nodes
+	}
+	for _, child := range node.Content {
+		nodes = append(nodes, findUsesNodes(child)...)
+	}
+	return nodes
+}
+
+// pinTarget is the pinned form of a reference.
+type pinTarget struct {
+	Ref     string // e.g. "actions/checkout@<sha>"
+	Version string // e.g. "v4.2.1"
+}
+
+// shaResolver resolves references to commit SHAs, remembering tags and results,
+// so that every repository's tags are only listed once per workflow.
+type shaResolver struct {
+	service *ActionsService
+	tags    map[string][]Tag
+	shas    map[string]*pinTarget
+}
+
+// resolve resolves a "uses:" value to its pinned form. If the reference is not to be pinned,
+// it returns a nil target and the status to report instead.
+func (r *shaResolver) resolve(ctx context.Context, uses string) (*pinTarget, string, error) {
+	if strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+		return nil, PinStatusSkipped, nil
+	}
+
+	ref, err := ParseActionRef(uses)
+	if err != nil {
+		return nil, "", err
+	}
+	if IsFullCommitSHA(ref.Version) {
+		return nil, PinStatusAlreadyPinned, nil
+	}
+
+	if target, ok := r.shas[uses]; ok {
+		return target, "", nil
+	}
+
+	sha, version, err := r.resolveVersion(ctx, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pinnedRef := *ref
+	pinnedRef.Version = sha
+	target := &pinTarget{Ref: pinnedRef.String(), Version: version}
+	r.shas[uses] = target
+	return target, "", nil
+}
+
+// resolveVersion resolves the version of ref to a commit SHA and the version to note in the comment.
+// Tags take precedence; versions which are not tags are resolved as branches or abbreviated SHAs.
+func (r *shaResolver) resolveVersion(ctx context.Context, ref *Ref) (sha, version string, err error) {
+	repository := ref.Owner + "/" + ref.Repo
+	tags, ok := r.tags[repository]
+	if !ok {
+		tags, err = r.service.ListTags(ctx, ref.Owner, ref.Repo)
+		if err != nil {
+			return "", "", err
+		}
+		r.tags[repository] = tags
+	}
+
+	if resolved, ok := resolveVersionFromTags(ref.Version, tags); ok {
+		return resolved.SHA, resolved.Tag, nil
+	}
+	if IsVersionSpec(ref.Version) {
+		return "", "", fmt.Errorf("no version of %s matches '%s': %w", repository, ref.Version, ErrNotFound)
+	}
+
+	sha, err = r.service.ResolveCommitSHA(ctx, ref.Owner, ref.Repo, ref.Version)
+	if err != nil {
+		return "", "", err
+	}
+	return sha, ref.Version, nil
+}
+
+// ResolveCommitSHA resolves a branch, tag or abbreviated commit SHA to the full SHA of the commit it points to.
+// If the ref does not exist, the returned error matches ErrNotFound.
+func (s *ActionsService) ResolveCommitSHA(ctx context.Context, owner, repo, ref string) (string, error) {
+	commitURL := fmt.Sprintf("%s/repos/%s/%s/commits/%s", s.endpoints.APIURL, url.PathEscape(owner), url.PathEscape(repo), escapePath(ref))
+	req, err := s.newRequest(ctx, commitURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request for commit: %w", err)
+	}
+	// Ask for the plain SHA instead of the full commit
+	req.Header.Set("Accept", "application/vnd.github.sha")
+
+	resp, err := s.get(req, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s/%s@%s: %w", owner, repo, ref, err)
+	}
+	// GitHub responds with 422 for refs that are neither branches, tags nor commits
+	if resp.StatusCode == 422 {
+		resp.StatusCode = 404
+	}
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("failed to resolve %s/%s@%s: %w", owner, repo, ref, newHTTPError(commitURL, resp))
+	}
+
+	sha := strings.TrimSpace(string(resp.Body))
+	if !IsFullCommitSHA(sha) {
+		return "", fmt.Errorf("failed to resolve %s/%s@%s: unexpected response %q", owner, repo, ref, sha)
+	}
+	return sha, nil
+}
+
+// versionCommentPattern matches trailing comments which only consist of a version, e.g. "# v4.2.1" or "#v4".
+var versionCommentPattern = regexp.MustCompile(`^#\s*v?\d+(\.\d+){0,2}(-[0-9A-Za-z.-]+)?\s*$`)
+
+// rewriteUsesLine replaces the "uses:" value node on line with pinnedRef, followed by a version comment.
+// It returns false if the value could not be found at the node's position.
+func rewriteUsesLine(line string, node *yaml.Node, pinnedRef, version string) (string, bool) {
+	// Lines of files with CRLF line endings keep their "\r", which is put back after the comment
+	line, terminator := strings.CutSuffix(line, "\r")
+	runes := []rune(line)
+	start := node.Column - 1
+	if start < 0 || start >= len(runes) {
+		return "", false
+	}
+
+	// Keep the quoting style of the original value
+	quote := ""
+	if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0 {
+		quote = string(runes[start])
+	}
+	original := quote + node.Value + quote
+	if !strings.HasPrefix(string(runes[start:]), original) {
+		return "", false
+	}
+
+	prefix := string(runes[:start])
+	rest := strings.TrimSpace(string(runes[start:])[len(original):])
+
+	comment := "# " + version
+	switch {
+	case rest == "":
+	case versionCommentPattern.MatchString(rest):
+		// Replace the version noted by an earlier update
+	case strings.HasPrefix(rest, "#"):
+		comment += " - " + strings.TrimSpace(strings.TrimPrefix(rest, "#"))
+	default:
+		// Anything else after the value, e.g. a flow mapping, cannot be rewritten safely
+		return "", false
+	}
+
+	if terminator {
+		comment += "\r"
+	}
+	return prefix + quote + pinnedRef + quote + " " + comment, true
+}
+
+// diffContextLines is the number of unchanged lines shown around changes in a unified diff.
+const diffContextLines = 3
+
+// unifiedDiff returns a unified diff between two versions of a file with the same number of lines,
+// where lines were only changed in place. It returns an empty string if nothing changed.
+func unifiedDiff(name string, before, after []string) string {
+	var changed []int
+	for i := range before {
+		if before[i] != after[i] {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", name, name)
+
+	for i := 0; i < len(changed); {
+		// Group changes whose context overlaps into a single hunk
+		j := i
+		for j+1 < len(changed) && changed[j+1]-changed[j] <= 2*diffContextLines {
+			j++
+		}
+		start := max(changed[i]-diffContextLines, 0)
+		end := min(changed[j]+diffContextLines+1, len(before))
+
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1, end-start)
+		for line := start; line < end; line++ {
+			if before[line] == after[line] {
+				fmt.Fprintf(&b, " %s\n", before[line])
+				continue
+			}
+			fmt.Fprintf(&b, "-%s\n+%s\n", before[line], after[line])
+		}
+		i = j + 1
+	}
+	return b.String()
+}
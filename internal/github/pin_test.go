@@ -0,0 +1,230 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	testSHA421  = "11bd71901bbe5b1630ceea73d27597364c9af683"
+	testSHAMain = "2c2b3bb7a0b3e7d1a5d6c0e6f2a4b8c9d0e1f2a3"
+)
+
+func TestRewriteUsesLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "plain",
+			line:   "      - uses: actions/checkout@v4",
+			want:   "      - uses: actions/checkout@" + testSHA421 + " # v4.2.1",
+			wantOK: true,
+		},
+		{
+			name:   "single quoted",
+			line:   "      - uses: 'actions/checkout@v4'",
+			want:   "      - uses: 'actions/checkout@" + testSHA421 + "' # v4.2.1",
+			wantOK: true,
+		},
+		{
+			name:   "double quoted",
+			line:   `        uses: "actions/checkout@v4"`,
+			want:   `        uses: "actions/checkout@` + testSHA421 + `" # v4.2.1`,
+			wantOK: true,
+		},
+		{
+			name:   "version comment is replaced",
+			line:   "      - uses: actions/checkout@v4 # v4.1.0",
+			want:   "      - uses: actions/checkout@" + testSHA421 + " # v4.2.1",
+			wantOK: true,
+		},
+		{
+			name:   "other comment is kept",
+			line:   "      - uses: actions/checkout@v4  # needed for tags",
+			want:   "      - uses: actions/checkout@" + testSHA421 + " # v4.2.1 - needed for tags",
+			wantOK: true,
+		},
+		{
+			name:   "CRLF line ending is kept",
+			line:   "      - uses: actions/checkout@v4\r",
+			want:   "      - uses: actions/checkout@" + testSHA421 + " # v4.2.1\r",
+			wantOK: true,
+		},
+		{
+			name:   "CRLF line ending after comment is kept",
+			line:   "      - uses: actions/checkout@v4 # needed for tags\r",
+			want:   "      - uses: actions/checkout@" + testSHA421 + " # v4.2.1 - needed for tags\r",
+			wantOK: true,
+		},
+		{
+			name:   "flow mapping",
+			line:   "    steps: [{uses: actions/checkout@v4, with: {fetch-depth: 0}}]",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.line), &document); err != nil {
+				t.Fatalf("Failed to parse line: %v", err)
+			}
+			nodes := findUsesNodes(&document)
+			if len(nodes) != 1 {
+				t.Fatalf("findUsesNodes() found %d nodes, want 1", len(nodes))
+			}
+
+			got, ok := rewriteUsesLine(tt.line, nodes[0], "actions/checkout@"+testSHA421, "v4.2.1")
+			if ok != tt.wantOK {
+				t.Fatalf("rewriteUsesLine() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("rewriteUsesLine() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	before := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"}
+	after := append([]string{}, before...)
+	after[1] = "B"
+	after[10] = "K"
+
+	want := `--- a/ci.yml
++++ b/ci.yml
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -8,5 +8,5 @@
+ h
+ i
+ j
+-k
++K
+ l
+`
+	if got := unifiedDiff("ci.yml", before, after); got != want {
+		t.Errorf("unifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+	if got := unifiedDiff("ci.yml", before, before); got != "" {
+		t.Errorf("unifiedDiff() = %q, want empty diff for unchanged lines", got)
+	}
+}
+
+func TestPinWorkflow(t *testing.T) {
+	workflow := `name: CI
+on: push
+
+jobs:
+  # Build and test
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4 # keep in sync with release.yml
+      - uses: actions/setup-node@main
+      - uses: ./.github/actions/setup
+      - uses: docker://alpine:3.20
+      - uses: actions/checkout@` + testSHA421 + `
+      - uses: actions/missing@v1
+  reuse:
+    uses: actions/checkout/.github/workflows/ci.yml@v4
+`
+
+	var commitRequests int
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/actions/checkout/tags":
+			fmt.Fprintf(w, `[{"name":"v4","commit":{"sha":"%s"}},{"name":"v4.2.1","commit":{"sha":"%s"}}]`, testSHA421, testSHA421)
+		case "/api/v3/repos/actions/setup-node/tags":
+			fmt.Fprint(w, `[]`)
+		case "/api/v3/repos/actions/setup-node/commits/main":
+			commitRequests++
+			if got := r.Header.Get("Accept"); got != "application/vnd.github.sha" {
+				t.Errorf("Accept header = %q, want application/vnd.github.sha", got)
+			}
+			fmt.Fprint(w, testSHAMain)
+		default:
+			http.NotFound(w, r)
+		}
+	}), false)
+
+	result, err := service.PinWorkflow(context.Background(), ".github/workflows/ci.yml", []byte(workflow))
+	if err != nil {
+		t.Fatalf("PinWorkflow() unexpected error: %v", err)
+	}
+
+	wantStatuses := []string{PinStatusPinned, PinStatusPinned, PinStatusSkipped, PinStatusSkipped, PinStatusAlreadyPinned, PinStatusFailed, PinStatusPinned}
+	if len(result.Actions) != len(wantStatuses) {
+		t.Fatalf("PinWorkflow() reported %d actions, want %d: %+v", len(result.Actions), len(wantStatuses), result.Actions)
+	}
+	for i, want := range wantStatuses {
+		if got := result.Actions[i].Status; got != want {
+			t.Errorf("Actions[%d] (%s) status = %q, want %q (reason: %s)", i, result.Actions[i].Original, got, want, result.Actions[i].Reason)
+		}
+	}
+
+	for _, want := range []string{
+		"  # Build and test\n",
+		"      - uses: actions/checkout@" + testSHA421 + " # v4.2.1 - keep in sync with release.yml\n",
+		"      - uses: actions/setup-node@" + testSHAMain + " # main\n",
+		"      - uses: ./.github/actions/setup\n",
+		"      - uses: actions/missing@v1\n",
+		"    uses: actions/checkout/.github/workflows/ci.yml@" + testSHA421 + " # v4.2.1\n",
+	} {
+		if !strings.Contains(result.Content, want) {
+			t.Errorf("PinWorkflow() content does not contain %q:\n%s", want, result.Content)
+		}
+	}
+	if got, want := strings.Count(result.Content, "\n"), strings.Count(workflow, "\n"); got != want {
+		t.Errorf("PinWorkflow() content has %d lines, want %d", got, want)
+	}
+
+	if !result.Changed() || !strings.HasPrefix(result.Diff, "--- a/.github/workflows/ci.yml\n+++ b/.github/workflows/ci.yml\n") {
+		t.Errorf("PinWorkflow() diff = %q, want a unified diff of the workflow", result.Diff)
+	}
+	if commitRequests != 1 {
+		t.Errorf("Commits API received %d requests, want 1", commitRequests)
+	}
+}
+
+func TestPinWorkflow_CRLF(t *testing.T) {
+	workflow := "jobs:\r\n  build:\r\n    steps:\r\n      - uses: actions/checkout@v4 # v4.1.0\r\n      - run: echo\r\n"
+
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/repos/actions/checkout/tags" {
+			fmt.Fprintf(w, `[{"name":"v4","commit":{"sha":"%s"}},{"name":"v4.2.1","commit":{"sha":"%s"}}]`, testSHA421, testSHA421)
+			return
+		}
+		http.NotFound(w, r)
+	}), false)
+
+	result, err := service.PinWorkflow(context.Background(), "ci.yml", []byte(workflow))
+	if err != nil {
+		t.Fatalf("PinWorkflow() unexpected error: %v", err)
+	}
+	want := "jobs:\r\n  build:\r\n    steps:\r\n      - uses: actions/checkout@" + testSHA421 + " # v4.2.1\r\n      - run: echo\r\n"
+	if result.Content != want {
+		t.Errorf("PinWorkflow() content =\n%q\nwant\n%q", result.Content, want)
+	}
+}
+
+func TestPinWorkflow_InvalidYAML(t *testing.T) {
+	service := NewActionsService(nil)
+
+	if _, err := service.PinWorkflow(context.Background(), "ci.yml", []byte("jobs: [")); err == nil {
+		t.Errorf("PinWorkflow() expected error for invalid YAML but got none")
+	}
+}
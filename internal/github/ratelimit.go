@@ -0,0 +1,72 @@
+package github
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is the state of a GitHub REST API rate limit, as reported in the X-RateLimit-* response headers.
+// See: https://docs.github.com/en/rest/using-the-rest-api/rate-limits-for-the-rest-api
+type RateLimit struct {
+	Limit     int       `json:"limit" jsonschema:"The maximum number of requests allowed in the current window"`
+	Remaining int       `json:"remaining" jsonschema:"The number of requests remaining in the current window"`
+	Reset     time.Time `json:"reset" jsonschema:"The time at which the current window resets"`
+	Resource  string    `json:"resource,omitempty" jsonschema:"The rate limit resource the requests are counted against, e.g. core"`
+}
+
+// parseRateLimit extracts the rate limit state from the headers of a response.
+// It returns false if the response does not carry rate limit headers,
+// which is the case for responses of the raw content host.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	reset, _ := parseUnixHeader(header, "X-RateLimit-Reset")
+	return RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     reset,
+		Resource:  header.Get("X-RateLimit-Resource"),
+	}, true
+}
+
+// rateLimitState holds the most recently observed rate limit and is safe for concurrent use.
+type rateLimitState struct {
+	mu    sync.Mutex
+	value RateLimit
+	known bool
+}
+
+// update records the rate limit reported in header, if any.
+func (s *rateLimitState) update(header http.Header) {
+	rateLimit, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.value = rateLimit
+	s.known = true
+}
+
+// get returns the most recently observed rate limit.
+func (s *rateLimitState) get() (RateLimit, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.value, s.known
+}
+
+// RateLimit returns the rate limit most recently reported by GitHub.
+// It returns false if no request has reported a rate limit yet. Files served from
+// the raw content host or from the cache do not count against the rate limit and
+// do not report one.
+func (s *ActionsService) RateLimit() (RateLimit, bool) {
+	return s.rateLimit.get()
+}
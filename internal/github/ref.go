@@ -1,32 +1,154 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
 )
 
 // Ref represents a parsed GitHub reference (repository or action).
 type Ref struct {
-	Owner   string
-	Repo    string
-	Version string // Can be a tag, branch, commit SHA, or version
+	Owner   string `json:"owner"`
+	Repo    string `json:"repo"`
+	Path    string `json:"path,omitempty"` // Optional path of a subdirectory within the repository, e.g. "init" for "github/codeql-action/init"
+	Version string `json:"version"`        // Can be a tag, branch, commit SHA, or version
 }
 
-// ParseRef parses a GitHub reference string like "owner/repo@version".
+// String formats the reference as "owner/repo[/path]@version".
+func (r *Ref) String() string {
+	repoPath := fmt.Sprintf("%s/%s", r.Owner, r.Repo)
+	if r.Path != "" {
+		repoPath = fmt.Sprintf("%s/%s", repoPath, r.Path)
+	}
+	return fmt.Sprintf("%s@%s", repoPath, r.Version)
+}
+
+// FilePath returns the path of filename relative to the repository root,
+// taking the reference's subdirectory into account.
+func (r *Ref) FilePath(filename string) string {
+	if r.Path == "" {
+		return filename
+	}
+	return path.Join(r.Path, filename)
+}
+
+// WorkflowsDir is the directory of a repository containing its workflows.
+const WorkflowsDir = ".github/workflows"
+
+// IsWorkflow reports whether the reference points to a workflow file,
+// e.g. "org/repo/.github/workflows/build.yml@v2" for a reusable workflow.
+func (r *Ref) IsWorkflow() bool {
+	dir, file := path.Split(r.Path)
+	extension := path.Ext(file)
+	return dir == WorkflowsDir+"/" && file != extension && (extension == ".yml" || extension == ".yaml")
+}
+
+// RefType describes the kind of Git reference a version string was matched against.
+type RefType string
+
+const (
+	// RefTypeCommit indicates the version is a commit SHA.
+	RefTypeCommit RefType = "commit"
+	// RefTypeTag indicates the version matched a tag.
+	RefTypeTag RefType = "tag"
+	// RefTypeBranch indicates the version matched a branch.
+	RefTypeBranch RefType = "branch"
+	// RefTypeImage indicates the version is the tag or digest of a Docker image ("docker://...").
+	RefTypeImage RefType = "image"
+	// RefTypeLocal indicates the version is the path of a local action or workflow in the workspace ("./...").
+	RefTypeLocal RefType = "local"
+)
+
+// ResolvedRef describes which Git reference a version was resolved to.
+// SHA is only known if the version was resolved through the repository's tags,
+// e.g. for version specs such as "latest" or "^4" (see ResolveActionVersion).
+type ResolvedRef struct {
+	Version string  `json:"version"`
+	Type    RefType `json:"type"`
+	SHA     string  `json:"sha,omitempty"`
+}
+
+var (
+	fullSHAPattern  = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+	shortSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,39}$`)
+)
+
+// IsFullCommitSHA reports whether version is a full 40-character commit SHA.
+func IsFullCommitSHA(version string) bool {
+	return fullSHAPattern.MatchString(version)
+}
+
+// IsShortCommitSHA reports whether version looks like an abbreviated commit SHA.
+// Note that short SHAs are ambiguous, as tags and branches may also consist of hex characters only.
+func IsShortCommitSHA(version string) bool {
+	return shortSHAPattern.MatchString(version)
+}
+
+// refCandidate is one way of addressing a version on GitHub.
+// The URLPath is a Git ref (e.g. "refs/tags/v5") or commit SHA, which is valid both as
+// a path segment on the raw content CDN and as the ref parameter of the contents API.
+type refCandidate struct {
+	Type    RefType
+	URLPath string
+}
+
+// refCandidates returns the URL paths to try for a version, in order of preference:
+//   - Full commit SHAs are immutable and only ever resolved as commits
+//   - Short commit SHAs are tried as commits first, then as tags and branches
+//   - Everything else is tried as a tag first, then as a branch
+func refCandidates(version string) []refCandidate {
+	commit := refCandidate{Type: RefTypeCommit, URLPath: version}
+	tag := refCandidate{Type: RefTypeTag, URLPath: fmt.Sprintf("refs/tags/%s", version)}
+	branch := refCandidate{Type: RefTypeBranch, URLPath: fmt.Sprintf("refs/heads/%s", version)}
+
+	switch {
+	case IsFullCommitSHA(version):
+		return []refCandidate{commit}
+	case IsShortCommitSHA(version):
+		return []refCandidate{commit, tag, branch}
+	default:
+		return []refCandidate{tag, branch}
+	}
+}
+
+// branchFirstRefCandidates returns the URL paths to try for a version like refCandidates, except
+// that a branch takes precedence over a tag with the same name.
+func branchFirstRefCandidates(version string) []refCandidate {
+	candidates := refCandidates(version)
+	if i := slices.IndexFunc(candidates, func(c refCandidate) bool { return c.Type == RefTypeTag }); i >= 0 {
+		tag := candidates[i]
+		candidates = append(slices.Delete(candidates, i, i+1), tag)
+	}
+	return candidates
+}
+
+// ParseRef parses a GitHub reference string like "owner/repo@version" or "owner/repo/path@version".
 // If requireVersion is true, the @version part is mandatory.
 // If requireVersion is false and no @version is provided, defaultVersion is used.
+// Malformed references are reported as errors matching ErrInvalidRef.
 //
 // Examples:
 //   - "actions/checkout@v5" -> {Owner: "actions", Repo: "checkout", Version: "v5"}
 //   - "owner/repo@main" -> {Owner: "owner", Repo: "repo", Version: "main"}
 //   - "owner/repo" with defaultVersion="main" -> {Owner: "owner", Repo: "repo", Version: "main"}
+//   - "github/codeql-action/init@v3" -> {Owner: "github", Repo: "codeql-action", Path: "init", Version: "v3"}
 func ParseRef(ref string, requireVersion bool, defaultVersion string) (*Ref, error) {
 	// Trim whitespace (including newlines, spaces, tabs)
 	ref = strings.TrimSpace(ref)
 
 	if ref == "" {
-		return nil, fmt.Errorf("reference cannot be empty")
+		return nil, invalidRef("reference cannot be empty")
+	}
+	if IsDockerRef(ref) {
+		return nil, invalidRef("'%s' references a Docker image, not a GitHub repository", ref)
+	}
+	if IsLocalRef(ref) {
+		return nil, invalidRef("'%s' references a local path, not a GitHub repository", ref)
 	}
 
 	var repoPath, version string
@@ -35,71 +157,132 @@ func ParseRef(ref string, requireVersion bool, defaultVersion string) (*Ref, err
 	if strings.Contains(ref, "@") {
 		parts := strings.Split(ref, "@")
 		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid reference format: expected 'owner/repo@version' or 'owner/repo', got '%s'", ref)
+			return nil, invalidRef("invalid reference format: expected 'owner/repo[/path]@version' or 'owner/repo[/path]', got '%s'", ref)
 		}
 		repoPath = parts[0]
 		version = parts[1]
 	} else {
 		// No @ found
 		if requireVersion {
-			return nil, fmt.Errorf("invalid reference format: expected 'owner/repo@version', got '%s'", ref)
+			return nil, invalidRef("invalid reference format: expected 'owner/repo[/path]@version', got '%s'", ref)
 		}
 		repoPath = ref
 		version = defaultVersion
 	}
 
-	// Split repo path by / to get owner and repo
+	// Split repo path by / to get owner, repo and the optional subdirectory path
 	repoParts := strings.Split(repoPath, "/")
-	if len(repoParts) != 2 {
-		return nil, fmt.Errorf("invalid repository path: expected 'owner/repo', got '%s'", repoPath)
+	if len(repoParts) < 2 {
+		return nil, invalidRef("invalid repository path: expected 'owner/repo[/path]', got '%s'", repoPath)
 	}
 
 	owner := repoParts[0]
 	repo := repoParts[1]
 
 	if owner == "" || repo == "" || version == "" {
-		return nil, fmt.Errorf("owner, repo, and version must all be non-empty")
+		return nil, invalidRef("owner, repo, and version must all be non-empty")
+	}
+	if err := validateRepoName("owner", owner); err != nil {
+		return nil, err
+	}
+	if err := validateRepoName("repository", repo); err != nil {
+		return nil, err
+	}
+
+	refPath := strings.Join(repoParts[2:], "/")
+	if len(repoParts) > 2 {
+		if err := validateRepoPath(refPath); err != nil {
+			return nil, invalidRef("invalid path in reference '%s': %v", ref, err)
+		}
 	}
 
 	return &Ref{
 		Owner:   owner,
 		Repo:    repo,
+		Path:    refPath,
 		Version: version,
 	}, nil
 }
 
+// repoNamePattern matches the characters GitHub allows in the names of owners and repositories.
+var repoNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// validateRepoName checks that the name of an owner or repository only consists of the characters
+// GitHub allows, so that it cannot change the target of the URLs it is used in.
+func validateRepoName(kind, name string) error {
+	if !repoNamePattern.MatchString(name) || name == "." || name == ".." {
+		return invalidRef("invalid %s '%s': expected letters, digits, '-', '_' and '.' only", kind, name)
+	}
+	return nil
+}
+
+// validateRepoPath checks that every segment of a slash-separated path in a repository
+// is non-empty and does not navigate outside of the repository.
+func validateRepoPath(p string) error {
+	for _, part := range strings.Split(p, "/") {
+		if part == "" || part == "." || part == ".." {
+			return invalidRef("segments must be non-empty and must not be '.' or '..'")
+		}
+	}
+	return nil
+}
+
 // FetchRawFile fetches a file from GitHub's raw content CDN.
 // The urlPath should specify the path type and version:
 //   - For tags: "refs/tags/{version}"
 //   - For branches: "refs/heads/{branch}"
 //   - For commits: "{sha}"
-func (s *ActionsService) FetchRawFile(owner, repo, urlPath, filename string) ([]byte, error) {
+//
+// If the configured endpoints have no raw content host (e.g. GitHub Enterprise Server),
+// the file is fetched from the REST API's contents endpoint instead, using urlPath as ref.
+// If the service has a cache, files are served from it when possible.
+// Unexpected responses are reported as *HTTPError, which can be matched against
+// ErrNotFound, ErrUnauthorized and ErrRateLimited with errors.Is.
+func (s *ActionsService) FetchRawFile(ctx context.Context, owner, repo, urlPath, filename string) ([]byte, error) {
 	// Construct URL to raw file on GitHub
-	url := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s",
-		owner, repo, urlPath, filename)
+	url := s.rawFileURL(owner, repo, urlPath, filename)
 
-	// Make HTTP GET request
-	resp, err := s.httpClient.Get(url)
+	// Make authenticated HTTP GET request
+	req, err := s.newRequest(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", filename, err)
+	}
+	if s.endpoints.RawURL == "" {
+		// Ask the contents API for the raw file content instead of JSON with base64-encoded content
+		req.Header.Set("Accept", "application/vnd.github.raw")
+	}
+	// Files at a full commit SHA never change, so they never need to be revalidated
+	resp, err := s.get(req, IsFullCommitSHA(urlPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch %s: %w", filename, err)
 	}
-	defer resp.Body.Close()
 
 	// Check for HTTP errors
 	if resp.StatusCode != 200 {
-		if resp.StatusCode == 404 {
-			return nil, fmt.Errorf("%s not found at %s (status: 404)", filename, url)
-		}
-		return nil, fmt.Errorf("failed to fetch %s from %s (status: %d)", filename, url, resp.StatusCode)
+		return nil, fmt.Errorf("failed to fetch %s: %w", filename, newHTTPError(url, resp))
 	}
 
-	// Read response body
-	data, err := readAllBody(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read %s response: %w", filename, err)
+	return resp.Body, nil
+}
+
+// rawFileURL returns the URL of a file at the given ref.
+// It points to the raw content host if available, or to the REST API's contents endpoint otherwise.
+func (s *ActionsService) rawFileURL(owner, repo, urlPath, filename string) string {
+	if s.endpoints.RawURL != "" {
+		return fmt.Sprintf("%s/%s/%s/%s/%s", s.endpoints.RawURL, url.PathEscape(owner), url.PathEscape(repo), escapePath(urlPath), escapePath(filename))
 	}
 
-	return data, nil
+	query := url.Values{"ref": []string{urlPath}}
+	return fmt.Sprintf("%s/repos/%s/%s/contents/%s?%s", s.endpoints.APIURL, url.PathEscape(owner), url.PathEscape(repo), escapePath(filename), query.Encode())
+}
+
+// escapePath escapes each segment of a slash-separated path for use in a URL.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
 }
 
 // readAllBody is a helper to read all data from an io.Reader.
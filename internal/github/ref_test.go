@@ -1,6 +1,7 @@
 package github
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -12,6 +13,7 @@ func TestParseRef(t *testing.T) {
 		defaultVersion string
 		wantOwner      string
 		wantRepo       string
+		wantPath       string
 		wantVersion    string
 		wantErr        bool
 	}{
@@ -95,6 +97,38 @@ func TestParseRef(t *testing.T) {
 			wantVersion:    "v1.0.0",
 			wantErr:        false,
 		},
+		{
+			name:           "valid names with dots, underscores and dashes",
+			input:          "my.org/my_repo-2.x@v1",
+			defaultVersion: "main",
+			wantOwner:      "my.org",
+			wantRepo:       "my_repo-2.x",
+			wantVersion:    "v1",
+		},
+		{
+			name:           "owner navigating up",
+			input:          "../repo@v1",
+			defaultVersion: "main",
+			wantErr:        true,
+		},
+		{
+			name:           "repository navigating up",
+			input:          "owner/..@v1",
+			defaultVersion: "main",
+			wantErr:        true,
+		},
+		{
+			name:           "repository with query",
+			input:          "owner/repo?x=@v1",
+			defaultVersion: "main",
+			wantErr:        true,
+		},
+		{
+			name:           "owner with encoded slash",
+			input:          "owner%2Fother/repo@v1",
+			defaultVersion: "main",
+			wantErr:        true,
+		},
 		{
 			name:           "empty string",
 			input:          "",
@@ -116,6 +150,13 @@ func TestParseRef(t *testing.T) {
 			defaultVersion: "",
 			wantErr:        true,
 		},
+		{
+			name:           "local path",
+			input:          "./.github/actions/setup",
+			requireVersion: false,
+			defaultVersion: "main",
+			wantErr:        true,
+		},
 		{
 			name:           "missing repo",
 			input:          "owner@v1",
@@ -124,8 +165,55 @@ func TestParseRef(t *testing.T) {
 			wantErr:        true,
 		},
 		{
-			name:           "invalid format - too many slashes",
-			input:          "owner/group/repo@v1",
+			name:           "reference with subdirectory path",
+			input:          "github/codeql-action/init@v3",
+			requireVersion: false,
+			defaultVersion: "main",
+			wantOwner:      "github",
+			wantRepo:       "codeql-action",
+			wantPath:       "init",
+			wantVersion:    "v3",
+			wantErr:        false,
+		},
+		{
+			name:           "reference with nested subdirectory path",
+			input:          "owner/repo/actions/setup@v1",
+			requireVersion: false,
+			defaultVersion: "main",
+			wantOwner:      "owner",
+			wantRepo:       "repo",
+			wantPath:       "actions/setup",
+			wantVersion:    "v1",
+			wantErr:        false,
+		},
+		{
+			name:           "reference with subdirectory path, defaults version",
+			input:          "owner/repo/docs",
+			requireVersion: false,
+			defaultVersion: "main",
+			wantOwner:      "owner",
+			wantRepo:       "repo",
+			wantPath:       "docs",
+			wantVersion:    "main",
+			wantErr:        false,
+		},
+		{
+			name:           "invalid format - empty path segment",
+			input:          "owner/repo//init@v1",
+			requireVersion: false,
+			defaultVersion: "main",
+			wantErr:        true,
+		},
+		{
+			name:           "invalid format - trailing slash",
+			input:          "owner/repo/@v1",
+			requireVersion: false,
+			defaultVersion: "main",
+			wantErr:        true,
+		},
+		{
+			name:           "invalid format - parent directory in path",
+			input:          "owner/repo/../other@v1",
 			requireVersion: false,
 			defaultVersion: "main",
 			wantErr:        true,
@@ -161,9 +249,173 @@ func TestParseRef(t *testing.T) {
 			if got.Repo != tt.wantRepo {
 				t.Errorf("ParseRef() Repo = %v, want %v", got.Repo, tt.wantRepo)
 			}
+			if got.Path != tt.wantPath {
+				t.Errorf("ParseRef() Path = %v, want %v", got.Path, tt.wantPath)
+			}
 			if got.Version != tt.wantVersion {
 				t.Errorf("ParseRef() Version = %v, want %v", got.Version, tt.wantVersion)
 			}
 		})
 	}
 }
+
+func TestRefString(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  Ref
+		want string
+	}{
+		{
+			name: "repository reference",
+			ref:  Ref{Owner: "actions", Repo: "checkout", Version: "v5"},
+			want: "actions/checkout@v5",
+		},
+		{
+			name: "reference with path",
+			ref:  Ref{Owner: "github", Repo: "codeql-action", Path: "init", Version: "v3"},
+			want: "github/codeql-action/init@v3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ref.String(); got != tt.want {
+				t.Errorf("Ref.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefFilePath(t *testing.T) {
+	root := Ref{Owner: "actions", Repo: "checkout", Version: "v5"}
+	if got := root.FilePath("action.yml"); got != "action.yml" {
+		t.Errorf("Ref.FilePath() = %v, want %v", got, "action.yml")
+	}
+
+	nested := Ref{Owner: "github", Repo: "codeql-action", Path: "init", Version: "v3"}
+	if got := nested.FilePath("action.yml"); got != "init/action.yml" {
+		t.Errorf("Ref.FilePath() = %v, want %v", got, "init/action.yml")
+	}
+}
+
+func TestRefCandidates(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    []refCandidate
+	}{
+		{
+			name:    "tag-like version tries tags before branches",
+			version: "v5",
+			want: []refCandidate{
+				{Type: RefTypeTag, URLPath: "refs/tags/v5"},
+				{Type: RefTypeBranch, URLPath: "refs/heads/v5"},
+			},
+		},
+		{
+			name:    "branch name tries tags before branches",
+			version: "main",
+			want: []refCandidate{
+				{Type: RefTypeTag, URLPath: "refs/tags/main"},
+				{Type: RefTypeBranch, URLPath: "refs/heads/main"},
+			},
+		},
+		{
+			name:    "full commit SHA is only resolved as commit",
+			version: "08c6903cd8c0fde910a37f88322edcfb5dd907a8",
+			want: []refCandidate{
+				{Type: RefTypeCommit, URLPath: "08c6903cd8c0fde910a37f88322edcfb5dd907a8"},
+			},
+		},
+		{
+			name:    "short commit SHA tries commit first",
+			version: "08c6903",
+			want: []refCandidate{
+				{Type: RefTypeCommit, URLPath: "08c6903"},
+				{Type: RefTypeTag, URLPath: "refs/tags/08c6903"},
+				{Type: RefTypeBranch, URLPath: "refs/heads/08c6903"},
+			},
+		},
+		{
+			name:    "too short hex string is not a SHA",
+			version: "abc123",
+			want: []refCandidate{
+				{Type: RefTypeTag, URLPath: "refs/tags/abc123"},
+				{Type: RefTypeBranch, URLPath: "refs/heads/abc123"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := refCandidates(tt.version)
+			if len(got) != len(tt.want) {
+				t.Fatalf("refCandidates() returned %d candidates, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("refCandidates()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBranchFirstRefCandidates(t *testing.T) {
+	tests := []struct {
+		version string
+		want    []RefType
+	}{
+		{version: "main", want: []RefType{RefTypeBranch, RefTypeTag}},
+		{version: "08c6903", want: []RefType{RefTypeCommit, RefTypeBranch, RefTypeTag}},
+		{version: "08c6903cd8c0fde910a37f88322edcfb5dd907a8", want: []RefType{RefTypeCommit}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			var got []RefType
+			for _, candidate := range branchFirstRefCandidates(tt.version) {
+				got = append(got, candidate.Type)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("branchFirstRefCandidates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsFullCommitSHA(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "08c6903cd8c0fde910a37f88322edcfb5dd907a8", want: true},
+		{input: "08C6903CD8C0FDE910A37F88322EDCFB5DD907A8", want: true},
+		{input: "08c6903", want: false},
+		{input: "v5", want: false},
+		{input: "08c6903cd8c0fde910a37f88322edcfb5dd907ag", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsFullCommitSHA(tt.input); got != tt.want {
+				t.Errorf("IsFullCommitSHA(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawFileURL(t *testing.T) {
+	service := NewActionsService(&ActionsServiceOptions{Endpoints: &Endpoints{
+		APIURL: "https://api.example.com",
+		RawURL: "https://raw.example.com",
+	}})
+	apiService := NewActionsService(&ActionsServiceOptions{Endpoints: &Endpoints{APIURL: "https://api.example.com"}})
+
+	if got, want := service.rawFileURL("owner", "repo", "refs/heads/fix#1?x", "dir/action.yml"), "https://raw.example.com/owner/repo/refs/heads/fix%231%3Fx/dir/action.yml"; got != want {
+		t.Errorf("rawFileURL() = %q, want %q", got, want)
+	}
+	if got, want := apiService.rawFileURL("owner", "repo", "refs/heads/fix#1", "action.yml"), "https://api.example.com/repos/owner/repo/contents/action.yml?ref=refs%2Fheads%2Ffix%231"; got != want {
+		t.Errorf("rawFileURL() = %q, want %q", got, want)
+	}
+}
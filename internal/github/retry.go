@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default retry settings used if ActionsServiceOptions does not specify them.
+const (
+	DefaultMaxRetries   = 3
+	DefaultMaxRetryWait = time.Minute
+)
+
+// Backoff delays between retries if GitHub does not say how long to wait.
+const (
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 30 * time.Second
+)
+
+// retryTransport retries idempotent requests which failed with a transient error.
+//
+// Requests are retried if sending them failed (e.g. the connection was reset or the attempt timed out),
+// if GitHub responded with a server error (5xx) or if a rate limit was hit (429, or 403 with an
+// exhausted rate limit). Between attempts it waits for the duration requested by GitHub through
+// Retry-After or X-RateLimit-Reset, or otherwise for an exponentially growing, jittered delay.
+// Waits longer than maxWait, or beyond the request context's deadline, are not attempted;
+// the last response is returned instead.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+
+	// attemptTimeout limits the duration of each attempt, including reading the response body.
+	attemptTimeout time.Duration
+
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	now       func() time.Time
+}
+
+// newRetryTransport creates a retryTransport sending requests through base.
+func newRetryTransport(base http.RoundTripper, maxRetries int, maxWait, attemptTimeout time.Duration) *retryTransport {
+	return &retryTransport{
+		base:           base,
+		maxRetries:     maxRetries,
+		maxWait:        maxWait,
+		attemptTimeout: attemptTimeout,
+		baseDelay:      retryBaseDelay,
+		maxDelay:       retryMaxDelay,
+		now:            time.Now,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTripAttempt(req)
+
+		// Give up on permanent errors, requests which cannot safely be resent,
+		// once all retries are used up or once the caller stopped waiting
+		if !isIdempotent(req) || attempt >= t.maxRetries || ctx.Err() != nil || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		delay := t.retryDelay(resp, attempt)
+		if delay > t.maxWait {
+			return resp, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && t.now().Add(delay).After(deadline) {
+			return resp, err
+		}
+
+		if resp != nil {
+			// Drain the body, so that the connection can be reused for the next attempt
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// roundTripAttempt sends a single attempt of req, limited to the attempt timeout.
+// The attempt's context is cancelled once the response body is closed.
+func (t *retryTransport) roundTripAttempt(req *http.Request) (*http.Response, error) {
+	if t.attemptTimeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.attemptTimeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// retryDelay returns how long to wait before sending the next attempt.
+// The delay requested by GitHub takes precedence over the exponential backoff.
+func (t *retryTransport) retryDelay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header, t.now()); ok {
+			return delay
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, ok := parseUnixHeader(resp.Header, "X-RateLimit-Reset"); ok {
+				return max(reset.Sub(t.now()), 0)
+			}
+		}
+	}
+
+	// Exponential backoff with jitter, so that concurrent clients do not retry in lockstep
+	delay := t.baseDelay << attempt
+	if delay <= 0 || delay > t.maxDelay {
+		delay = t.maxDelay
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// isIdempotent reports whether req can safely be sent again.
+func isIdempotent(req *http.Request) bool {
+	return (req.Method == http.MethodGet || req.Method == http.MethodHead) && (req.Body == nil || req.Body == http.NoBody)
+}
+
+// shouldRetry reports whether an attempt failed with a transient error.
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode >= 500:
+		return true
+	case resp.StatusCode == http.StatusForbidden:
+		// GitHub responds with 403 for both exhausted primary rate limits and
+		// secondary rate limits, the latter carrying a Retry-After header
+		return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+	default:
+		return false
+	}
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// parseUnixHeader parses a header holding a Unix timestamp in seconds.
+func parseUnixHeader(header http.Header, name string) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(header.Get(name), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
+
+// cancelOnCloseBody cancels the context of a request once its response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
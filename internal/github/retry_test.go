@@ -0,0 +1,288 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripFunc adapts a function to an http.RoundTripper.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestResponse creates a response with the given status code and headers (as name/value pairs).
+func newTestResponse(status int, headers ...string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(http.StatusText(status))),
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		resp.Header.Set(headers[i], headers[i+1])
+	}
+	return resp
+}
+
+// newFastRetryTransport creates a retryTransport replaying the given responses and
+// recording how often it was called, with backoff delays short enough for tests.
+func newFastRetryTransport(responses []*http.Response, calls *int) *retryTransport {
+	transport := newRetryTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp := responses[min(*calls, len(responses)-1)]
+		*calls++
+		if resp == nil {
+			return nil, errors.New("connection reset by peer")
+		}
+		return resp, nil
+	}), 3, time.Minute, 0)
+	transport.baseDelay = time.Millisecond
+	transport.maxDelay = 10 * time.Millisecond
+	return transport
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		responses  []*http.Response
+		wantStatus int
+		wantErr    bool
+		wantCalls  int
+	}{
+		{
+			name:       "success is not retried",
+			responses:  []*http.Response{newTestResponse(http.StatusOK)},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "not found is not retried",
+			responses:  []*http.Response{newTestResponse(http.StatusNotFound)},
+			wantStatus: http.StatusNotFound,
+			wantCalls:  1,
+		},
+		{
+			name:       "bad gateway is retried",
+			responses:  []*http.Response{newTestResponse(http.StatusBadGateway), newTestResponse(http.StatusOK)},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "too many requests is retried",
+			responses:  []*http.Response{newTestResponse(http.StatusTooManyRequests, "Retry-After", "0"), newTestResponse(http.StatusOK)},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "connection error is retried",
+			responses:  []*http.Response{nil, newTestResponse(http.StatusOK)},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "forbidden with exhausted rate limit is retried",
+			responses:  []*http.Response{newTestResponse(http.StatusForbidden, "X-RateLimit-Remaining", "0", "X-RateLimit-Reset", "0"), newTestResponse(http.StatusOK)},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "forbidden without rate limit is not retried",
+			responses:  []*http.Response{newTestResponse(http.StatusForbidden, "X-RateLimit-Remaining", "4999")},
+			wantStatus: http.StatusForbidden,
+			wantCalls:  1,
+		},
+		{
+			name:       "gives up after max retries",
+			responses:  []*http.Response{newTestResponse(http.StatusServiceUnavailable)},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  4,
+		},
+		{
+			name:      "gives up on connection errors after max retries",
+			responses: []*http.Response{nil},
+			wantErr:   true,
+			wantCalls: 4,
+		},
+		{
+			name:       "retry after beyond max wait is not retried",
+			responses:  []*http.Response{newTestResponse(http.StatusTooManyRequests, "Retry-After", "3600")},
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  1,
+		},
+		{
+			name:       "non-idempotent request is not retried",
+			method:     http.MethodPost,
+			responses:  []*http.Response{newTestResponse(http.StatusBadGateway)},
+			wantStatus: http.StatusBadGateway,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			transport := newFastRetryTransport(tt.responses, &calls)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			var body io.Reader
+			if method == http.MethodPost {
+				body = strings.NewReader("{}")
+			}
+			req := httptest.NewRequest(method, "https://api.github.com/repos/actions/checkout", body)
+
+			resp, err := transport.RoundTrip(req)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("RoundTrip() expected error but got none")
+				}
+			} else if err != nil {
+				t.Fatalf("RoundTrip() unexpected error: %v", err)
+			} else if resp.StatusCode != tt.wantStatus {
+				t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("RoundTrip() sent %d attempts, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_RespectsContextDeadline(t *testing.T) {
+	calls := 0
+	transport := newFastRetryTransport([]*http.Response{newTestResponse(http.StatusTooManyRequests, "Retry-After", "30")}, &calls)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/actions/checkout", nil).WithContext(ctx)
+
+	start := time.Now()
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if calls != 1 {
+		t.Errorf("RoundTrip() sent %d attempts, want 1", calls)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("RoundTrip() took %v, want it to give up without waiting past the deadline", elapsed)
+	}
+}
+
+func TestRetryTransport_RetryDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	transport := newRetryTransport(nil, 3, time.Minute, 0)
+	transport.now = func() time.Time { return now }
+
+	tests := []struct {
+		name    string
+		resp    *http.Response
+		attempt int
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{
+			name:    "retry after seconds",
+			resp:    newTestResponse(http.StatusTooManyRequests, "Retry-After", "7"),
+			wantMin: 7 * time.Second,
+			wantMax: 7 * time.Second,
+		},
+		{
+			name:    "retry after date",
+			resp:    newTestResponse(http.StatusServiceUnavailable, "Retry-After", now.Add(20*time.Second).Format(http.TimeFormat)),
+			wantMin: 20 * time.Second,
+			wantMax: 20 * time.Second,
+		},
+		{
+			name:    "rate limit reset",
+			resp:    newTestResponse(http.StatusForbidden, "X-RateLimit-Remaining", "0", "X-RateLimit-Reset", strconv.FormatInt(now.Add(42*time.Second).Unix(), 10)),
+			wantMin: 42 * time.Second,
+			wantMax: 42 * time.Second,
+		},
+		{
+			name:    "rate limit reset in the past",
+			resp:    newTestResponse(http.StatusForbidden, "X-RateLimit-Remaining", "0", "X-RateLimit-Reset", strconv.FormatInt(now.Add(-time.Minute).Unix(), 10)),
+			wantMin: 0,
+			wantMax: 0,
+		},
+		{
+			name:    "first backoff",
+			resp:    newTestResponse(http.StatusBadGateway),
+			wantMin: retryBaseDelay / 2,
+			wantMax: retryBaseDelay,
+		},
+		{
+			name:    "third backoff",
+			resp:    newTestResponse(http.StatusBadGateway),
+			attempt: 2,
+			wantMin: 2 * retryBaseDelay,
+			wantMax: 4 * retryBaseDelay,
+		},
+		{
+			name:    "backoff is capped",
+			resp:    newTestResponse(http.StatusBadGateway),
+			attempt: 20,
+			wantMin: retryMaxDelay / 2,
+			wantMax: retryMaxDelay,
+		},
+		{
+			name:    "connection error",
+			attempt: 1,
+			wantMin: retryBaseDelay,
+			wantMax: 2 * retryBaseDelay,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := transport.retryDelay(tt.resp, tt.attempt)
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("retryDelay() = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestActionsService_RateLimit(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.Header().Set("X-RateLimit-Reset", "1735732800")
+		w.Header().Set("X-RateLimit-Resource", "core")
+		if r.URL.Path == "/api/v3/repos/actions/checkout/contents/action.yml" {
+			w.Write([]byte("name: Checkout\nruns:\n  using: node20\n"))
+			return
+		}
+		http.NotFound(w, r)
+	}), true)
+
+	if _, ok := service.RateLimit(); ok {
+		t.Errorf("RateLimit() reported a rate limit before any request was sent")
+	}
+
+	if _, _, err := service.GetActionParameters(context.Background(), "actions/checkout@v5"); err != nil {
+		t.Fatalf("GetActionParameters() unexpected error: %v", err)
+	}
+
+	rateLimit, ok := service.RateLimit()
+	if !ok {
+		t.Fatalf("RateLimit() reported no rate limit after a request")
+	}
+	want := RateLimit{Limit: 5000, Remaining: 4321, Reset: time.Unix(1735732800, 0), Resource: "core"}
+	if !rateLimit.Reset.Equal(want.Reset) || rateLimit.Limit != want.Limit || rateLimit.Remaining != want.Remaining || rateLimit.Resource != want.Resource {
+		t.Errorf("RateLimit() = %+v, want %+v", rateLimit, want)
+	}
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxSearchResults is the number of repositories requested from the search API, the most it returns per page.
+const maxSearchResults = 100
+
+// SearchRepositories lists the names of the repositories of an owner (a user or organization) whose
+// name starts with prefix, ignoring case, using the REST API's repository search. Repositories are
+// sorted by stars, so the most popular ones come first; at most 100 are listed.
+//
+// The search API has a lower rate limit than the other endpoints (10 requests per minute without
+// a token, 30 with one), so callers completing user input should not search on every keystroke.
+func (s *ActionsService) SearchRepositories(ctx context.Context, owner, prefix string) ([]string, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if owner == "" {
+		return nil, invalidRef("owner is required")
+	}
+	// Both are part of the search query, which must not be extended with other qualifiers
+	if err := validateRepoName("owner", owner); err != nil {
+		return nil, err
+	}
+	if prefix != "" && !repoNamePattern.MatchString(prefix) {
+		return nil, invalidRef("invalid repository prefix '%s': expected letters, digits, '-', '_' and '.' only", prefix)
+	}
+
+	// The search matches words anywhere in the name, e.g. "node" matches "setup-node",
+	// so results are filtered by prefix afterwards
+	q := "user:" + owner + " fork:true"
+	if prefix != "" {
+		q = prefix + " in:name " + q
+	}
+	query := url.Values{"q": []string{q}, "sort": []string{"stars"}, "per_page": []string{fmt.Sprint(maxSearchResults)}}
+	searchURL := fmt.Sprintf("%s/search/repositories?%s", s.endpoints.APIURL, query.Encode())
+
+	req, err := s.newRequest(ctx, searchURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for repository search: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := s.get(req, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search repositories of %s: %w", owner, err)
+	}
+	// The search API answers 422 for owners that do not exist
+	if resp.StatusCode == 422 {
+		return nil, fmt.Errorf("failed to search repositories of %s: %w", owner, ErrNotFound)
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to search repositories of %s: %w", owner, newHTTPError(searchURL, resp))
+	}
+
+	var result struct {
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse repository search results: %w", err)
+	}
+	names := []string{}
+	for _, item := range result.Items {
+		if strings.HasPrefix(strings.ToLower(item.Name), strings.ToLower(prefix)) {
+			names = append(names, item.Name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestSearchRepositories(t *testing.T) {
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/search/repositories" {
+			http.NotFound(w, r)
+			return
+		}
+		switch q := r.URL.Query().Get("q"); q {
+		case "setup- in:name user:actions fork:true":
+			// The search also matches words in the middle of names
+			fmt.Fprint(w, `{"items": [{"name": "setup-node"}, {"name": "Setup-Python"}, {"name": "go-setup-helper"}]}`)
+		case "user:actions fork:true":
+			fmt.Fprint(w, `{"items": [{"name": "checkout"}, {"name": "setup-node"}]}`)
+		case "user:missing fork:true":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		default:
+			t.Errorf("unexpected query %q", q)
+			http.NotFound(w, r)
+		}
+	}), false)
+
+	tests := []struct {
+		name    string
+		owner   string
+		prefix  string
+		want    []string
+		wantErr error
+	}{
+		{name: "prefix", owner: "actions", prefix: "setup-", want: []string{"setup-node", "Setup-Python"}},
+		{name: "no prefix", owner: "actions", want: []string{"checkout", "setup-node"}},
+		{name: "unknown owner", owner: "missing", wantErr: ErrNotFound},
+		{name: "no owner", wantErr: ErrInvalidRef},
+		{name: "owner with qualifier", owner: "actions user:other", wantErr: ErrInvalidRef},
+		{name: "prefix with qualifier", owner: "actions", prefix: "setup user:other", wantErr: ErrInvalidRef},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.SearchRepositories(context.Background(), tt.owner, tt.prefix)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("SearchRepositories() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SearchRepositories() unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SearchRepositories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version parsed from a tag name such as "v4.2.1".
+// Tags of actions commonly omit components, e.g. the moving major tag "v4",
+// which is reflected in Precision.
+// See: https://semver.org
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	// Precision is the number of numeric components given, e.g. 1 for "v4" and 3 for "v4.2.1".
+	Precision int
+}
+
+var versionPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)(?:\.(0|[1-9]\d*))?(?:\.(0|[1-9]\d*))?(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// ParseVersion parses a version with an optional "v" prefix and optional minor and patch components,
+// e.g. "v4", "4.1", "v4.2.1" or "v5.0.0-beta.1". Build metadata is ignored.
+// It returns false if s is not a semantic version.
+func ParseVersion(s string) (Version, bool) {
+	match := versionPattern.FindStringSubmatch(s)
+	if match == nil {
+		return Version{}, false
+	}
+
+	var v Version
+	for i, component := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if match[i+1] == "" {
+			break
+		}
+		value, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return Version{}, false
+		}
+		*component = value
+		v.Precision++
+	}
+
+	// Only complete versions can be pre-releases
+	if match[4] != "" && v.Precision < 3 {
+		return Version{}, false
+	}
+	v.Prerelease = match[4]
+	return v, true
+}
+
+// IsRelease reports whether v is a complete version, as opposed to a moving tag such as "v4".
+func (v Version) IsRelease() bool {
+	return v.Precision == 3
+}
+
+// String formats the version with a "v" prefix and as many components as were given.
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d", v.Major)
+	if v.Precision >= 2 {
+		s += fmt.Sprintf(".%d", v.Minor)
+	}
+	if v.Precision >= 3 {
+		s += fmt.Sprintf(".%d", v.Patch)
+	}
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 depending on whether v precedes, equals or follows other.
+// Missing components count as zero, and pre-releases precede the corresponding release.
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// comparePrerelease compares two pre-release strings according to the semver precedence rules:
+// identifiers are compared one by one, numerically if both are numeric and lexically otherwise.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				return sign(aNum - bNum)
+			}
+		case aErr == nil:
+			// Numeric identifiers have lower precedence than alphanumeric ones
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return sign(len(aParts) - len(bParts))
+}
+
+// sign returns -1, 0 or +1 depending on the sign of n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// VersionLatest is the version spec selecting the newest stable release.
+const VersionLatest = "latest"
+
+// IsVersionSpec reports whether version is a spec which must be resolved against the
+// repository's tags before it can be fetched: "latest" or a range such as "^4" or "~4.1".
+func IsVersionSpec(version string) bool {
+	return version == VersionLatest || strings.HasPrefix(version, "^") || strings.HasPrefix(version, "~")
+}
+
+// versionMatcher reports whether a release satisfies a version spec.
+type versionMatcher func(Version) bool
+
+// parseVersionRange parses a version range into a matcher for stable releases.
+// Supported ranges follow npm's semantics:
+//   - "latest" matches every release
+//   - "^4", "^4.1" match releases with the same major version (>=4.1.0 <5.0.0),
+//     or the same minor version for 0.x versions ("^0.3" is >=0.3.0 <0.4.0)
+//   - "~4.1" matches releases with the same minor version (>=4.1.0 <4.2.0); "~4" is the same as "^4"
+//   - Partial versions such as "v4" or "4.1" match releases starting with them
+func parseVersionRange(spec string) (versionMatcher, bool) {
+	if spec == VersionLatest {
+		return func(Version) bool { return true }, true
+	}
+
+	operator := ""
+	if strings.HasPrefix(spec, "^") || strings.HasPrefix(spec, "~") {
+		operator, spec = spec[:1], spec[1:]
+	}
+	base, ok := ParseVersion(spec)
+	if !ok || base.Prerelease != "" {
+		return nil, false
+	}
+
+	// The number of leading components a matching release must share with base
+	shared := base.Precision
+	switch operator {
+	case "^":
+		shared = 1
+		if base.Major == 0 && base.Precision >= 2 {
+			shared = 2
+		}
+	case "~":
+		shared = min(base.Precision, 2)
+	}
+
+	return func(v Version) bool {
+		if v.Compare(Version{Major: base.Major, Minor: base.Minor, Patch: base.Patch}) < 0 {
+			return false
+		}
+		if shared >= 1 && v.Major != base.Major {
+			return false
+		}
+		if shared >= 2 && v.Minor != base.Minor {
+			return false
+		}
+		if shared >= 3 && v.Patch != base.Patch {
+			return false
+		}
+		return true
+	}, true
+}
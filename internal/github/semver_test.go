@@ -0,0 +1,108 @@
+package github
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   Version
+		wantOK bool
+	}{
+		{input: "v4", want: Version{Major: 4, Precision: 1}, wantOK: true},
+		{input: "v4.1", want: Version{Major: 4, Minor: 1, Precision: 2}, wantOK: true},
+		{input: "v4.2.1", want: Version{Major: 4, Minor: 2, Patch: 1, Precision: 3}, wantOK: true},
+		{input: "4.2.1", want: Version{Major: 4, Minor: 2, Patch: 1, Precision: 3}, wantOK: true},
+		{input: "v5.0.0-beta.1", want: Version{Major: 5, Prerelease: "beta.1", Precision: 3}, wantOK: true},
+		{input: "v1.0.0+build.5", want: Version{Major: 1, Precision: 3}, wantOK: true},
+		{input: "v4-beta", wantOK: false},
+		{input: "v01.2.3", wantOK: false},
+		{input: "v1.2.3.4", wantOK: false},
+		{input: "main", wantOK: false},
+		{input: "releases/v1", wantOK: false},
+		{input: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseVersion(tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseVersion(%q) ok = %v, want %v", tt.input, ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "v4.2.1", b: "v4.2.1", want: 0},
+		{a: "v4.2.1", b: "v4.10.0", want: -1},
+		{a: "v10.0.0", b: "v9.9.9", want: 1},
+		{a: "v5.0.0-beta.1", b: "v5.0.0", want: -1},
+		{a: "v5.0.0-beta.2", b: "v5.0.0-beta.10", want: -1},
+		{a: "v5.0.0-alpha", b: "v5.0.0-beta", want: -1},
+		{a: "v5.0.0-1", b: "v5.0.0-alpha", want: -1},
+		{a: "v5.0.0-alpha", b: "v5.0.0-alpha.1", want: -1},
+		{a: "v4", b: "v4.0.0", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, _ := ParseVersion(tt.a)
+			b, _ := ParseVersion(tt.b)
+			if got := a.Compare(b); got != tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := b.Compare(a); got != -tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersionRange(t *testing.T) {
+	tests := []struct {
+		spec      string
+		matches   []string
+		noMatches []string
+		wantErr   bool
+	}{
+		{spec: "latest", matches: []string{"v0.0.1", "v4.2.1", "v10.0.0"}},
+		{spec: "^4", matches: []string{"v4.0.0", "v4.9.9"}, noMatches: []string{"v3.9.9", "v5.0.0"}},
+		{spec: "^4.1", matches: []string{"v4.1.0", "v4.5.0"}, noMatches: []string{"v4.0.9", "v5.0.0"}},
+		{spec: "^0.3", matches: []string{"v0.3.0", "v0.3.9"}, noMatches: []string{"v0.2.9", "v0.4.0"}},
+		{spec: "~4.1", matches: []string{"v4.1.0", "v4.1.7"}, noMatches: []string{"v4.0.9", "v4.2.0"}},
+		{spec: "~4", matches: []string{"v4.0.0", "v4.3.0"}, noMatches: []string{"v5.0.0"}},
+		{spec: "v4", matches: []string{"v4.0.0", "v4.3.0"}, noMatches: []string{"v3.0.0", "v5.0.0"}},
+		{spec: "v4.2", matches: []string{"v4.2.0", "v4.2.3"}, noMatches: []string{"v4.1.0", "v4.3.0"}},
+		{spec: "v4.2.1", matches: []string{"v4.2.1"}, noMatches: []string{"v4.2.0", "v4.2.2"}},
+		{spec: "main", wantErr: true},
+		{spec: "^main", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			matches, ok := parseVersionRange(tt.spec)
+			if ok == tt.wantErr {
+				t.Fatalf("parseVersionRange(%q) ok = %v, want %v", tt.spec, ok, !tt.wantErr)
+			}
+			for _, version := range tt.matches {
+				v, _ := ParseVersion(version)
+				if !matches(v) {
+					t.Errorf("parseVersionRange(%q) does not match %s", tt.spec, version)
+				}
+			}
+			for _, version := range tt.noMatches {
+				v, _ := ParseVersion(version)
+				if matches(v) {
+					t.Errorf("parseVersionRange(%q) matches %s", tt.spec, version)
+				}
+			}
+		})
+	}
+}
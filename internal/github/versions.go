@@ -0,0 +1,241 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// maxTagPages limits the number of pages of 100 tags fetched for a repository.
+const maxTagPages = 10
+
+// Tag is a Git tag of a repository and the commit it points to.
+type Tag struct {
+	Name string `json:"name"`
+	SHA  string `json:"sha"`
+}
+
+// ActionVersion is a tag of an action's repository that is a semantic version.
+type ActionVersion struct {
+	Tag        string `json:"tag" jsonschema:"The tag name, e.g. v4.2.1 or v4"`
+	SHA        string `json:"sha" jsonschema:"The full SHA of the commit the tag points to"`
+	Prerelease bool   `json:"prerelease,omitempty" jsonschema:"Whether the version is a pre-release"`
+	Release    string `json:"release,omitempty" jsonschema:"For moving tags such as v4, the exact release pointing to the same commit, e.g. v4.2.1"`
+}
+
+// ActionVersions lists the versions of an action, newest first.
+type ActionVersions struct {
+	Repository string          `json:"repository" jsonschema:"The repository, e.g. actions/checkout"`
+	Latest     *ActionVersion  `json:"latest,omitempty" jsonschema:"The newest stable release"`
+	Releases   []ActionVersion `json:"releases" jsonschema:"Tags that are complete semantic versions (e.g. v4.2.1), newest first"`
+	MovingTags []ActionVersion `json:"movingTags" jsonschema:"Major and minor tags (e.g. v4 or v4.2) that are moved to the newest matching release, newest first"`
+
+	// tags are all tags of the repository, including those that are not semantic versions.
+	tags []Tag
+}
+
+// Resolve resolves a version or version spec against the listed tags, without sending further requests.
+// See ResolveActionVersion for the supported specs. It returns false if no tag matches.
+func (v *ActionVersions) Resolve(spec string) (*ResolvedVersion, bool) {
+	return resolveVersionFromTags(spec, v.tags)
+}
+
+// ResolvedVersion is the tag and commit a version or version spec was resolved to.
+type ResolvedVersion struct {
+	Spec string `json:"spec" jsonschema:"The requested version or version spec, e.g. latest, ^4 or v4"`
+	Tag  string `json:"tag" jsonschema:"The tag the version was resolved to, preferably an exact release such as v4.2.1"`
+	SHA  string `json:"sha" jsonschema:"The full SHA of the commit the tag points to"`
+}
+
+// ListTags lists the tags of a repository using the REST API.
+// At most 1000 tags are listed, which covers the history of all but the most prolific repositories.
+func (s *ActionsService) ListTags(ctx context.Context, owner, repo string) ([]Tag, error) {
+	var tags []Tag
+	for page := 1; page <= maxTagPages; page++ {
+		query := url.Values{"per_page": []string{"100"}, "page": []string{fmt.Sprint(page)}}
+		pageURL := fmt.Sprintf("%s/repos/%s/%s/tags?%s", s.endpoints.APIURL, url.PathEscape(owner), url.PathEscape(repo), query.Encode())
+
+		req, err := s.newRequest(ctx, pageURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request for tags: %w", err)
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+
+		resp, err := s.get(req, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s/%s: %w", owner, repo, err)
+		}
+		if resp.StatusCode != 200 {
+			return nil, fmt.Errorf("failed to list tags of %s/%s: %w", owner, repo, newHTTPError(pageURL, resp))
+		}
+
+		var pageTags []struct {
+			Name   string `json:"name"`
+			Commit struct {
+				SHA string `json:"sha"`
+			} `json:"commit"`
+		}
+		if err := json.Unmarshal(resp.Body, &pageTags); err != nil {
+			return nil, fmt.Errorf("failed to parse tags of %s/%s: %w", owner, repo, err)
+		}
+		for _, tag := range pageTags {
+			tags = append(tags, Tag{Name: tag.Name, SHA: tag.Commit.SHA})
+		}
+
+		if len(pageTags) < 100 {
+			break
+		}
+	}
+	return tags, nil
+}
+
+// ListActionVersions lists the versions of the action in the given repository (e.g. "actions/checkout"),
+// sorted semantically with the newest version first. Tags which are not semantic versions are omitted.
+// Moving tags such as "v4" are reported together with the exact release pointing to the same commit.
+func (s *ActionsService) ListActionVersions(ctx context.Context, repository string) (*ActionVersions, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	ref, err := ParseRepoRef(repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository reference: %w", err)
+	}
+
+	tags, err := s.ListTags(ctx, ref.Owner, ref.Repo)
+	if err != nil {
+		return nil, err
+	}
+	return actionVersionsFromTags(ref.Owner+"/"+ref.Repo, tags), nil
+}
+
+// ResolveActionVersion resolves the version of an action reference to a tag and commit SHA.
+// The version may be:
+//   - "latest", resolving to the newest stable release
+//   - A range such as "^4" or "~4.1" (see parseVersionRange), resolving to the newest matching stable release
+//   - A moving tag such as "v4", resolving to the exact release pointing to the same commit
+//     (or, if no such tag exists, the newest matching release)
+//   - Any other tag, resolving to itself
+//
+// If no tag matches, the returned error matches ErrNotFound.
+func (s *ActionsService) ResolveActionVersion(ctx context.Context, actionRef string) (*ResolvedVersion, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	ref, err := ParseActionRef(actionRef)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action reference: %w", err)
+	}
+	return s.resolveVersion(ctx, ref)
+}
+
+// resolveVersion resolves ref.Version against the tags of ref's repository (see ResolveActionVersion).
+func (s *ActionsService) resolveVersion(ctx context.Context, ref *Ref) (*ResolvedVersion, error) {
+	tags, err := s.ListTags(ctx, ref.Owner, ref.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, ok := resolveVersionFromTags(ref.Version, tags)
+	if !ok {
+		return nil, fmt.Errorf("no version of %s/%s matches '%s': %w", ref.Owner, ref.Repo, ref.Version, ErrNotFound)
+	}
+	return resolved, nil
+}
+
+// versionedTag is a tag whose name was parsed as a semantic version.
+type versionedTag struct {
+	Tag
+	Version Version
+}
+
+// parseVersionedTags returns the tags which are semantic versions, newest first.
+// Among tags with equal versions (e.g. "v4.0.0" and "4.0.0"), the original order is kept.
+func parseVersionedTags(tags []Tag) []versionedTag {
+	var versioned []versionedTag
+	for _, tag := range tags {
+		if version, ok := ParseVersion(tag.Name); ok {
+			versioned = append(versioned, versionedTag{Tag: tag, Version: version})
+		}
+	}
+	slices.SortStableFunc(versioned, func(a, b versionedTag) int {
+		return b.Version.Compare(a.Version)
+	})
+	return versioned
+}
+
+// actionVersionsFromTags builds the sorted version listing of a repository from its tags.
+func actionVersionsFromTags(repository string, tags []Tag) *ActionVersions {
+	versioned := parseVersionedTags(tags)
+
+	result := &ActionVersions{
+		Repository: repository,
+		Releases:   []ActionVersion{},
+		MovingTags: []ActionVersion{},
+		tags:       tags,
+	}
+	for _, tag := range versioned {
+		if tag.Version.IsRelease() {
+			version := ActionVersion{Tag: tag.Name, SHA: tag.SHA, Prerelease: tag.Version.Prerelease != ""}
+			result.Releases = append(result.Releases, version)
+			if result.Latest == nil && !version.Prerelease {
+				result.Latest = &version
+			}
+			continue
+		}
+
+		version := ActionVersion{Tag: tag.Name, SHA: tag.SHA}
+		if release, ok := releaseForMovingTag(tag, versioned); ok {
+			version.Release = release.Name
+		}
+		result.MovingTags = append(result.MovingTags, version)
+	}
+	return result
+}
+
+// releaseForMovingTag finds the newest release a moving tag such as "v4" points to,
+// i.e. a release starting with the moving tag's components and pointing to the same commit.
+// versioned must be sorted newest first.
+func releaseForMovingTag(moving versionedTag, versioned []versionedTag) (versionedTag, bool) {
+	matches, _ := parseVersionRange(moving.Version.String())
+	for _, tag := range versioned {
+		if tag.Version.IsRelease() && tag.SHA == moving.SHA && matches(tag.Version) {
+			return tag, true
+		}
+	}
+	return versionedTag{}, false
+}
+
+// resolveVersionFromTags resolves a version or version spec against a repository's tags
+// (see ResolveActionVersion).
+func resolveVersionFromTags(spec string, tags []Tag) (*ResolvedVersion, bool) {
+	versioned := parseVersionedTags(tags)
+
+	// A tag with exactly the requested name is used as is, unless it is a moving tag,
+	// in which case the release it currently points to is preferred
+	if !IsVersionSpec(spec) {
+		for _, tag := range tags {
+			if tag.Name != spec {
+				continue
+			}
+			if version, ok := ParseVersion(tag.Name); ok && !version.IsRelease() {
+				if release, ok := releaseForMovingTag(versionedTag{Tag: tag, Version: version}, versioned); ok {
+					return &ResolvedVersion{Spec: spec, Tag: release.Name, SHA: release.SHA}, true
+				}
+			}
+			return &ResolvedVersion{Spec: spec, Tag: tag.Name, SHA: tag.SHA}, true
+		}
+	}
+
+	matches, ok := parseVersionRange(spec)
+	if !ok {
+		return nil, false
+	}
+	for _, tag := range versioned {
+		if tag.Version.IsRelease() && tag.Version.Prerelease == "" && matches(tag.Version) {
+			return &ResolvedVersion{Spec: spec, Tag: tag.Name, SHA: tag.SHA}, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// testTags are the tags of a fictional action, in the order returned by the tags API.
+var testTags = []Tag{
+	{Name: "v5.0.0-beta.1", SHA: "sha-500b1"},
+	{Name: "v4.2.1", SHA: "sha-421"},
+	{Name: "v4.2.0", SHA: "sha-420"},
+	{Name: "v4.10.0", SHA: "sha-4100"},
+	{Name: "v4", SHA: "sha-4100"},
+	{Name: "v4.2", SHA: "sha-421"},
+	{Name: "v3.6.0", SHA: "sha-360"},
+	{Name: "v3", SHA: "sha-3-moved"},
+	{Name: "nightly", SHA: "sha-nightly"},
+}
+
+func TestActionVersionsFromTags(t *testing.T) {
+	versions := actionVersionsFromTags("actions/example", testTags)
+
+	var releases []string
+	for _, release := range versions.Releases {
+		releases = append(releases, release.Tag)
+	}
+	if got, want := strings.Join(releases, ","), "v5.0.0-beta.1,v4.10.0,v4.2.1,v4.2.0,v3.6.0"; got != want {
+		t.Errorf("Releases = %s, want %s", got, want)
+	}
+	if !versions.Releases[0].Prerelease {
+		t.Errorf("Releases[0].Prerelease = false, want true for %s", versions.Releases[0].Tag)
+	}
+
+	if versions.Latest == nil || versions.Latest.Tag != "v4.10.0" {
+		t.Errorf("Latest = %+v, want v4.10.0", versions.Latest)
+	}
+
+	var movingTags []string
+	for _, tag := range versions.MovingTags {
+		movingTags = append(movingTags, tag.Tag+"="+tag.Release)
+	}
+	if got, want := strings.Join(movingTags, ","), "v4.2=v4.2.1,v4=v4.10.0,v3="; got != want {
+		t.Errorf("MovingTags = %s, want %s", got, want)
+	}
+}
+
+func TestResolveVersionFromTags(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantTag string
+		wantSHA string
+		wantOK  bool
+	}{
+		{spec: "latest", wantTag: "v4.10.0", wantSHA: "sha-4100", wantOK: true},
+		{spec: "^4", wantTag: "v4.10.0", wantSHA: "sha-4100", wantOK: true},
+		{spec: "~4.2", wantTag: "v4.2.1", wantSHA: "sha-421", wantOK: true},
+		{spec: "^3", wantTag: "v3.6.0", wantSHA: "sha-360", wantOK: true},
+		{spec: "v4", wantTag: "v4.10.0", wantSHA: "sha-4100", wantOK: true},
+		{spec: "v4.2", wantTag: "v4.2.1", wantSHA: "sha-421", wantOK: true},
+		{spec: "v4.2.0", wantTag: "v4.2.0", wantSHA: "sha-420", wantOK: true},
+		{spec: "v5.0.0-beta.1", wantTag: "v5.0.0-beta.1", wantSHA: "sha-500b1", wantOK: true},
+		{spec: "nightly", wantTag: "nightly", wantSHA: "sha-nightly", wantOK: true},
+		// v3 points to a commit without an exact release, so it is used as is
+		{spec: "v3", wantTag: "v3", wantSHA: "sha-3-moved", wantOK: true},
+		// There is no v4.10 tag, so the newest matching release is used
+		{spec: "v4.10", wantTag: "v4.10.0", wantSHA: "sha-4100", wantOK: true},
+		// There is neither a v4.1 tag nor a matching release
+		{spec: "v4.1", wantOK: false},
+		{spec: "^5", wantOK: false},
+		{spec: "main", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, ok := resolveVersionFromTags(tt.spec, testTags)
+			if ok != tt.wantOK {
+				t.Fatalf("resolveVersionFromTags(%q) ok = %v, want %v", tt.spec, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.Tag != tt.wantTag || got.SHA != tt.wantSHA || got.Spec != tt.spec {
+				t.Errorf("resolveVersionFromTags(%q) = %+v, want tag %s at %s", tt.spec, got, tt.wantTag, tt.wantSHA)
+			}
+		})
+	}
+}
+
+// tagsHandler serves tags in pages of 100 from the tags API of actions/example.
+func tagsHandler(t *testing.T, tags []Tag, fallback http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/repos/actions/example/tags" {
+			fallback(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("per_page"); got != "100" {
+			t.Errorf("per_page = %q, want 100", got)
+		}
+
+		var page int
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		start, end := min((page-1)*100, len(tags)), min(page*100, len(tags))
+
+		type apiTag struct {
+			Name   string `json:"name"`
+			Commit struct {
+				SHA string `json:"sha"`
+			} `json:"commit"`
+		}
+		body := []apiTag{}
+		for _, tag := range tags[start:end] {
+			entry := apiTag{Name: tag.Name}
+			entry.Commit.SHA = tag.SHA
+			body = append(body, entry)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+func TestListTags_Pagination(t *testing.T) {
+	var tags []Tag
+	for i := range 150 {
+		tags = append(tags, Tag{Name: fmt.Sprintf("v1.0.%d", i), SHA: fmt.Sprintf("sha-%d", i)})
+	}
+	service := newTestService(t, tagsHandler(t, tags, http.NotFound), false)
+
+	got, err := service.ListTags(context.Background(), "actions", "example")
+	if err != nil {
+		t.Fatalf("ListTags() unexpected error: %v", err)
+	}
+	if len(got) != len(tags) {
+		t.Errorf("ListTags() returned %d tags, want %d", len(got), len(tags))
+	}
+}
+
+func TestListTags_NotFound(t *testing.T) {
+	service := newTestService(t, http.NotFoundHandler(), false)
+
+	_, err := service.ListTags(context.Background(), "actions", "missing")
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "actions/missing") {
+		t.Errorf("ListTags() error = %v, want a not found error mentioning the repository", err)
+	}
+}
+
+func TestGetActionParameters_ResolvesLatest(t *testing.T) {
+	service := newTestService(t, tagsHandler(t, testTags, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/raw/actions/example/refs/tags/v4.10.0/action.yml" {
+			fmt.Fprint(w, "name: Example\nruns:\n  using: node20\n  main: index.js\n")
+			return
+		}
+		http.NotFound(w, r)
+	}), false)
+
+	_, resolved, err := service.GetActionParameters(context.Background(), "actions/example@latest")
+	if err != nil {
+		t.Fatalf("GetActionParameters() unexpected error: %v", err)
+	}
+	want := ResolvedRef{Version: "v4.10.0", Type: RefTypeTag, SHA: "sha-4100"}
+	if *resolved != want {
+		t.Errorf("GetActionParameters() resolved = %+v, want %+v", *resolved, want)
+	}
+}
+
+func TestActionVersions_Resolve(t *testing.T) {
+	versions := actionVersionsFromTags("actions/example", testTags)
+
+	resolved, ok := versions.Resolve("nightly")
+	if !ok || resolved.SHA != "sha-nightly" {
+		t.Errorf("Resolve(nightly) = %+v, %v, want the nightly tag, which is not a semantic version", resolved, ok)
+	}
+	if _, ok := versions.Resolve("^9"); ok {
+		t.Errorf("Resolve(^9) ok = true, want false")
+	}
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// ParseWorkflowRef parses a reusable workflow reference like "owner/repo/.github/workflows/build.yml@version",
+// as used by jobs calling reusable workflows. The version part is required, and the path must point
+// to a .yml or .yaml file in the repository's .github/workflows directory.
+// Examples:
+//   - "octo-org/ci/.github/workflows/build.yml@v2" -> {Owner: "octo-org", Repo: "ci", Path: ".github/workflows/build.yml", Version: "v2"}
+func ParseWorkflowRef(ref string) (*Ref, error) {
+	parsed, err := ParseRef(ref, true, "")
+	if err != nil {
+		return nil, err
+	}
+	if !parsed.IsWorkflow() {
+		return nil, invalidRef("invalid workflow reference: expected 'owner/repo/%s/<file>.yml@version', got '%s'", WorkflowsDir, ref)
+	}
+	return parsed, nil
+}
+
+// GetWorkflowYAML fetches a workflow file from GitHub (see FetchRawFile).
+// It takes a reusable workflow reference (e.g., "octo-org/ci/.github/workflows/build.yml@v2") and returns
+// the content of the workflow file, together with the kind of ref (commit, tag or branch) the version was resolved to.
+// Version specs such as "latest" or "^2" are first resolved to the matching release (see ResolveActionVersion).
+// Local workflows referenced as "./.github/workflows/<file>.yml" are read from the workspace (see ReadLocalFile).
+func (s *ActionsService) GetWorkflowYAML(ctx context.Context, workflowRef string) ([]byte, *ResolvedRef, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if IsLocalRef(workflowRef) {
+		workflowPath, err := ParseLocalRef(workflowRef)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid workflow reference: %w", err)
+		}
+		if !(&Ref{Path: workflowPath}).IsWorkflow() {
+			return nil, nil, invalidRef("invalid workflow reference: expected './%s/<file>.yml', got '%s'", WorkflowsDir, workflowRef)
+		}
+		data, err := s.ReadLocalFile(ctx, workflowPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return data, &ResolvedRef{Version: workflowPath, Type: RefTypeLocal}, nil
+	}
+
+	// Parse the workflow reference
+	ref, err := ParseWorkflowRef(workflowRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid workflow reference: %w", err)
+	}
+
+	// Resolve version specs to a release tag
+	var sha string
+	if IsVersionSpec(ref.Version) {
+		version, err := s.resolveVersion(ctx, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		ref.Version, sha = version.Tag, version.SHA
+	}
+
+	data, resolved, err := s.FetchFile(ctx, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved.SHA = sha
+	return data, resolved, nil
+}
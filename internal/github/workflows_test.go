@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestParseWorkflowRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantPath string
+		wantErr  bool
+	}{
+		{name: "yml file", input: "octo-org/ci/.github/workflows/build.yml@v2", wantPath: ".github/workflows/build.yml"},
+		{name: "yaml file", input: "octo-org/ci/.github/workflows/release.yaml@main", wantPath: ".github/workflows/release.yaml"},
+		{name: "missing version", input: "octo-org/ci/.github/workflows/build.yml", wantErr: true},
+		{name: "action reference", input: "actions/checkout@v5", wantErr: true},
+		{name: "not a YAML file", input: "octo-org/ci/.github/workflows/build.json@v2", wantErr: true},
+		{name: "nested directory", input: "octo-org/ci/.github/workflows/nested/build.yml@v2", wantErr: true},
+		{name: "outside of workflows directory", input: "octo-org/ci/workflows/build.yml@v2", wantErr: true},
+		{name: "extension only", input: "octo-org/ci/.github/workflows/.yml@v2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWorkflowRef(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRef) {
+					t.Errorf("ParseWorkflowRef() error = %v, want ErrInvalidRef", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWorkflowRef() unexpected error: %v", err)
+			}
+			if got.Path != tt.wantPath {
+				t.Errorf("ParseWorkflowRef() Path = %q, want %q", got.Path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestGetWorkflowYAML_ResolvesVersionSpec(t *testing.T) {
+	tags := []Tag{{Name: "v2.1.0", SHA: "sha-2.1.0"}, {Name: "v2.0.0", SHA: "sha-2.0.0"}}
+	service := newTestService(t, tagsHandler(t, tags, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/raw/actions/example/refs/tags/v2.1.0/.github/workflows/build.yml" {
+			fmt.Fprint(w, "on: workflow_call\n")
+			return
+		}
+		http.NotFound(w, r)
+	}), false)
+
+	data, resolved, err := service.GetWorkflowYAML(context.Background(), "actions/example/.github/workflows/build.yml@latest")
+	if err != nil {
+		t.Fatalf("GetWorkflowYAML() unexpected error: %v", err)
+	}
+	if string(data) != "on: workflow_call\n" {
+		t.Errorf("GetWorkflowYAML() = %q, want the workflow file", data)
+	}
+	want := ResolvedRef{Version: "v2.1.0", Type: RefTypeTag, SHA: "sha-2.1.0"}
+	if *resolved != want {
+		t.Errorf("GetWorkflowYAML() resolved = %+v, want %+v", *resolved, want)
+	}
+}
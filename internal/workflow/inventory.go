@@ -0,0 +1,97 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// Kinds of ActionReference.
+const (
+	// KindAction is an action in a GitHub repository, e.g. "actions/checkout@v5".
+	KindAction = "action"
+	// KindReusableWorkflow is a reusable workflow called by a job, e.g. "org/repo/.github/workflows/build.yml@v2".
+	KindReusableWorkflow = "reusable_workflow"
+	// KindLocal is an action or reusable workflow in the same repository, e.g. "./.github/actions/setup".
+	KindLocal = "local"
+	// KindDocker is a Docker image, e.g. "docker://alpine:3.20".
+	KindDocker = "docker"
+)
+
+// ActionReference is a single "uses:" reference in a workflow.
+type ActionReference struct {
+	Uses   string `json:"uses" jsonschema:"The value of uses: as written in the workflow"`
+	Kind   string `json:"kind" jsonschema:"One of action, reusable_workflow, local or docker"`
+	Job    string `json:"job" jsonschema:"The ID of the job containing the reference"`
+	Step   string `json:"step,omitempty" jsonschema:"The name, ID or action of the step containing the reference, empty for jobs calling reusable workflows"`
+	Line   int    `json:"line" jsonschema:"The line of the reference in the workflow"`
+	Column int    `json:"column" jsonschema:"The column of the reference in the workflow"`
+	// Ref is the parsed reference; it is only set for actions and reusable workflows in GitHub repositories.
+	Ref *github.Ref `json:"ref,omitempty" jsonschema:"The parsed reference, for actions and reusable workflows in GitHub repositories"`
+	// Image is the parsed Docker image; it is only set for Docker images.
+	Image *github.DockerImage `json:"image,omitempty" jsonschema:"The parsed image, for Docker images"`
+	// Error reports why the reference could not be parsed.
+	Error string `json:"error,omitempty" jsonschema:"Why the reference could not be parsed"`
+}
+
+// Inventory returns every action and reusable workflow referenced by the workflow,
+// in the order they appear in the file.
+func (w *Workflow) Inventory() []ActionReference {
+	references := []ActionReference{}
+	for _, job := range w.Jobs {
+		if job.Uses != "" {
+			reference := newActionReference(job.Uses, job.UsesPosition)
+			reference.Job = job.ID
+			if reference.Kind == KindAction {
+				reference.Kind = KindReusableWorkflow
+				if reference.Ref != nil && !reference.Ref.IsWorkflow() {
+					reference.Error = fmt.Sprintf("'%s' is not a workflow file in %s", reference.Ref.Path, github.WorkflowsDir)
+					reference.Ref = nil
+				}
+			}
+			references = append(references, reference)
+		}
+		for _, step := range job.Steps {
+			if step.Uses == "" {
+				continue
+			}
+			reference := newActionReference(step.Uses, step.UsesPosition)
+			reference.Job = job.ID
+			reference.Step = step.Label()
+			references = append(references, reference)
+		}
+	}
+	return references
+}
+
+// newActionReference classifies and parses the value of a "uses:" key.
+func newActionReference(uses string, position Position) ActionReference {
+	reference := ActionReference{
+		Uses:   uses,
+		Line:   position.Line,
+		Column: position.Column,
+	}
+
+	switch {
+	case strings.HasPrefix(uses, "./"):
+		reference.Kind = KindLocal
+	case github.IsDockerRef(uses):
+		reference.Kind = KindDocker
+		image, err := github.ParseDockerImage(uses)
+		if err != nil {
+			reference.Error = err.Error()
+			break
+		}
+		reference.Image = image
+	default:
+		reference.Kind = KindAction
+		ref, err := github.ParseActionRef(uses)
+		if err != nil {
+			reference.Error = err.Error()
+			break
+		}
+		reference.Ref = ref
+	}
+	return reference
+}
@@ -0,0 +1,72 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+func TestWorkflow_Inventory(t *testing.T) {
+	data := []byte(`on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v5
+      - name: Analyze
+        uses: github/codeql-action/analyze@v3
+      - uses: ./.github/actions/setup
+      - uses: docker://alpine:3.20
+      - uses: not-a-reference
+      - run: make
+  release:
+    uses: org/workflows/.github/workflows/release.yml@v2
+  deploy:
+    uses: org/workflows/deploy@v2
+`)
+
+	workflow, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	got := workflow.Inventory()
+	want := []ActionReference{
+		{
+			Uses: "actions/checkout@v5", Kind: KindAction, Job: "build", Step: "actions/checkout@v5", Line: 6, Column: 15,
+			Ref: &github.Ref{Owner: "actions", Repo: "checkout", Version: "v5"},
+		},
+		{
+			Uses: "github/codeql-action/analyze@v3", Kind: KindAction, Job: "build", Step: "Analyze", Line: 8, Column: 15,
+			Ref: &github.Ref{Owner: "github", Repo: "codeql-action", Path: "analyze", Version: "v3"},
+		},
+		{Uses: "./.github/actions/setup", Kind: KindLocal, Job: "build", Step: "./.github/actions/setup", Line: 9, Column: 15},
+		{
+			Uses: "docker://alpine:3.20", Kind: KindDocker, Job: "build", Step: "docker://alpine:3.20", Line: 10, Column: 15,
+			Image: &github.DockerImage{Repository: "alpine", Tag: "3.20"},
+		},
+		{
+			Uses: "org/workflows/.github/workflows/release.yml@v2", Kind: KindReusableWorkflow, Job: "release", Line: 14, Column: 11,
+			Ref: &github.Ref{Owner: "org", Repo: "workflows", Path: ".github/workflows/release.yml", Version: "v2"},
+		},
+	}
+
+	if len(got) != 7 {
+		t.Fatalf("Inventory() returned %d references, want 7: %+v", len(got), got)
+	}
+	// Jobs can only call workflow files
+	if deploy := got[6]; deploy.Kind != KindReusableWorkflow || deploy.Ref != nil || deploy.Error == "" {
+		t.Errorf("Inventory()[6] = %+v, want a reusable workflow reference with an error", deploy)
+	}
+	got = got[:6]
+	// The invalid reference is reported with an error instead of a parsed ref
+	invalid := got[4]
+	if invalid.Uses != "not-a-reference" || invalid.Ref != nil || invalid.Error == "" {
+		t.Errorf("Inventory()[4] = %+v, want an unparsed reference with an error", invalid)
+	}
+	got = append(got[:4], got[5])
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Inventory() =\n%+v\nwant\n%+v", got, want)
+	}
+}
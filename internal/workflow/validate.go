@@ -0,0 +1,294 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// Kinds of Finding.
+const (
+	// FindingUnknownInput is an input set with "with:" which the action does not declare.
+	FindingUnknownInput = "unknown_input"
+	// FindingMissingRequiredInput is a required input without default which is not set.
+	FindingMissingRequiredInput = "missing_required_input"
+	// FindingDeprecatedInput is an input set although the action marks it as deprecated.
+	FindingDeprecatedInput = "deprecated_input"
+	// FindingUnknownOutput is a reference to a step output which the action does not declare.
+	FindingUnknownOutput = "unknown_output"
+	// FindingActionUnavailable is an action whose action.yml could not be fetched.
+	FindingActionUnavailable = "action_unavailable"
+)
+
+// Severities of a Finding.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Finding is a single problem found when validating a workflow.
+type Finding struct {
+	Kind       string `json:"kind" jsonschema:"One of unknown_input, missing_required_input, deprecated_input, unknown_output or action_unavailable"`
+	Severity   string `json:"severity" jsonschema:"One of error or warning"`
+	Message    string `json:"message" jsonschema:"A human-readable description of the problem"`
+	Job        string `json:"job" jsonschema:"The ID of the job containing the problem"`
+	Step       string `json:"step,omitempty" jsonschema:"The name, ID or action of the step containing the problem"`
+	Uses       string `json:"uses,omitempty" jsonschema:"The action the problem refers to"`
+	Input      string `json:"input,omitempty" jsonschema:"The input the problem refers to"`
+	Output     string `json:"output,omitempty" jsonschema:"The output the problem refers to"`
+	Suggestion string `json:"suggestion,omitempty" jsonschema:"The declared input or output that was most likely meant"`
+	Line       int    `json:"line" jsonschema:"The line of the problem in the workflow"`
+	Column     int    `json:"column" jsonschema:"The column of the problem in the workflow"`
+}
+
+// String formats the finding as "line:column: severity: message".
+func (f *Finding) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", f.Line, f.Column, f.Severity, f.Message)
+}
+
+// ValidationResult is the result of validating a workflow.
+type ValidationResult struct {
+	// ActionsChecked is the number of distinct actions whose inputs and outputs were checked.
+	ActionsChecked int       `json:"actionsChecked" jsonschema:"The number of distinct actions whose inputs and outputs were checked"`
+	Findings       []Finding `json:"findings" jsonschema:"The problems found, in order of their position in the workflow"`
+}
+
+// HasErrors reports whether any finding has error severity.
+func (r *ValidationResult) HasErrors() bool {
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerInputs are the inputs accepted by every Docker action, overriding its args and entrypoint.
+var dockerInputs = []string{"args", "entrypoint"}
+
+// Validate checks the "with:" inputs of every step using an action against the inputs the
+// action declares, fetched with GetActionParameters. It reports unknown inputs (suggesting
+// the declared input that was most likely meant, e.g. "node-version" for "node_version"),
+// missing required inputs, deprecated inputs, and references to step outputs the action does not declare.
+//
+// Local actions are read from the service's workspace and only checked if it is known
+// (see github.ActionsService.Workspace). Docker images and reusable workflows are not checked.
+// Actions which cannot be fetched are reported as findings; an error is only returned if ctx is done.
+func Validate(ctx context.Context, service *github.ActionsService, workflow *Workflow) (*ValidationResult, error) {
+	result := &ValidationResult{Findings: []Finding{}}
+	actions := map[string]*github.ActionMetadata{}
+	failed := map[string]bool{}
+	hasWorkspace := service.Workspace(ctx) != ""
+
+	for _, job := range workflow.Jobs {
+		stepActions := map[string]*github.ActionMetadata{}
+		stepUses := map[string]string{}
+
+		for _, step := range job.Steps {
+			if step.Uses == "" {
+				continue
+			}
+			if reference := newActionReference(step.Uses, step.UsesPosition); reference.Ref == nil && (reference.Kind != KindLocal || !hasWorkspace) {
+				continue
+			}
+
+			metadata, ok := actions[step.Uses]
+			if !ok && !failed[step.Uses] {
+				var err error
+				metadata, _, err = service.GetActionParameters(ctx, step.Uses)
+				if err != nil {
+					if ctxErr := ctx.Err(); ctxErr != nil {
+						return nil, ctxErr
+					}
+					failed[step.Uses] = true
+					result.Findings = append(result.Findings, Finding{
+						Kind:     FindingActionUnavailable,
+						Severity: SeverityWarning,
+						Message:  fmt.Sprintf("could not check %s: %v", step.Uses, err),
+						Job:      job.ID,
+						Step:     step.Label(),
+						Uses:     step.Uses,
+						Line:     step.UsesPosition.Line,
+						Column:   step.UsesPosition.Column,
+					})
+					continue
+				}
+				actions[step.Uses] = metadata
+			}
+			if metadata == nil {
+				continue
+			}
+
+			result.Findings = append(result.Findings, validateStepInputs(job, step, metadata)...)
+			if step.ID != "" {
+				stepActions[step.ID] = metadata
+				stepUses[step.ID] = step.Uses
+			}
+		}
+
+		for _, reference := range job.StepOutputReferences {
+			metadata, ok := stepActions[reference.StepID]
+			if !ok || hasKey(metadata.Outputs, reference.Output) {
+				continue
+			}
+			finding := Finding{
+				Kind:     FindingUnknownOutput,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("%s does not declare output '%s' referenced as steps.%s.outputs.%s", stepUses[reference.StepID], reference.Output, reference.StepID, reference.Output),
+				Job:      job.ID,
+				Uses:     stepUses[reference.StepID],
+				Output:   reference.Output,
+				Line:     reference.Position.Line,
+				Column:   reference.Position.Column,
+			}
+			if suggestion := suggestName(reference.Output, slices.Sorted(maps.Keys(metadata.Outputs))); suggestion != "" {
+				finding.Suggestion = suggestion
+				finding.Message += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+			}
+			result.Findings = append(result.Findings, finding)
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	result.ActionsChecked = len(actions)
+	return result, nil
+}
+
+// validateStepInputs checks the inputs of a step against the inputs declared by its action.
+func validateStepInputs(job *Job, step *Step, metadata *github.ActionMetadata) []Finding {
+	var findings []Finding
+	newFinding := func(kind, severity, input string, position Position, message string) Finding {
+		return Finding{
+			Kind:     kind,
+			Severity: severity,
+			Message:  message,
+			Job:      job.ID,
+			Step:     step.Label(),
+			Uses:     step.Uses,
+			Input:    input,
+			Line:     position.Line,
+			Column:   position.Column,
+		}
+	}
+
+	declared := slices.Sorted(maps.Keys(metadata.Inputs))
+	if metadata.Runs.IsDocker() {
+		declared = append(declared, dockerInputs...)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(step.With)) {
+		position := step.WithPositions[name]
+		input, ok := lookupInput(metadata.Inputs, name)
+		switch {
+		case ok && input.DeprecationMessage != "":
+			findings = append(findings, newFinding(FindingDeprecatedInput, SeverityWarning, name, position,
+				fmt.Sprintf("input '%s' of %s is deprecated: %s", name, step.Uses, input.DeprecationMessage)))
+		case ok || containsFold(declared, name):
+		default:
+			finding := newFinding(FindingUnknownInput, SeverityError, name, position,
+				fmt.Sprintf("%s has no input '%s'", step.Uses, name))
+			if suggestion := suggestName(name, declared); suggestion != "" {
+				finding.Suggestion = suggestion
+				finding.Message += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(metadata.Inputs)) {
+		input := metadata.Inputs[name]
+		if !input.Required || input.Default != "" || hasKey(step.With, name) {
+			continue
+		}
+		findings = append(findings, newFinding(FindingMissingRequiredInput, SeverityError, name, step.Position,
+			fmt.Sprintf("required input '%s' of %s is not set", name, step.Uses)))
+	}
+	return findings
+}
+
+// lookupInput looks up an input by name. Input names are case-insensitive.
+func lookupInput(inputs map[string]github.ActionInput, name string) (github.ActionInput, bool) {
+	for key, input := range inputs {
+		if strings.EqualFold(key, name) {
+			return input, true
+		}
+	}
+	return github.ActionInput{}, false
+}
+
+// hasKey reports whether m has a key equal to name under case folding.
+func hasKey[V any](m map[string]V, name string) bool {
+	for key := range m {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether names contains name under case folding.
+func containsFold(names []string, name string) bool {
+	for _, candidate := range names {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestName returns the candidate most likely meant by a misspelled name, or an empty string if none is close.
+// Names differing only in case and separators (e.g. "node_version" and "node-version") match first;
+// otherwise the candidate with the smallest edit distance is chosen if it is at most a third of the name's length.
+func suggestName(name string, candidates []string) string {
+	normalized := normalizeName(name)
+	for _, candidate := range candidates {
+		if normalizeName(candidate) == normalized {
+			return candidate
+		}
+	}
+
+	best, bestDistance := "", max(len(name)/3, 1)+1
+	for _, candidate := range candidates {
+		if distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// normalizeName lowercases a name and removes separators.
+func normalizeName(name string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(name))
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,187 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/techprimate/github-actions-utils-cli/internal/github"
+)
+
+// newTestService creates an ActionsService whose raw content host is served by handler.
+func newTestService(t *testing.T, handler http.Handler) *github.ActionsService {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return github.NewActionsService(&github.ActionsServiceOptions{
+		Endpoints: &github.Endpoints{
+			WebURL: server.URL,
+			APIURL: server.URL + "/api/v3",
+			RawURL: server.URL + "/raw",
+		},
+		MaxRetries: -1,
+	})
+}
+
+func TestValidate(t *testing.T) {
+	var requests int
+	service := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/raw/actions/setup-node/refs/tags/v4/action.yml":
+			fmt.Fprint(w, `name: Setup Node
+inputs:
+  node-version:
+    description: Version of Node.js
+  token:
+    required: true
+    default: ${{ github.token }}
+  always-auth:
+    deprecationMessage: No longer needed
+outputs:
+  cache-hit:
+    description: Whether the cache was hit
+runs:
+  using: node20
+  main: index.js
+`)
+		case "/raw/org/deploy/refs/tags/v1/action.yml":
+			fmt.Fprint(w, "name: Deploy\ninputs:\n  environment:\n    required: true\nruns:\n  using: docker\n  image: Dockerfile\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	workflow, err := Parse([]byte(`on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - id: node
+        uses: actions/setup-node@v4
+        with:
+          node_version: 20
+          always-auth: true
+      - uses: actions/setup-node@v4
+        with:
+          Node-Version: 22
+      - uses: org/deploy@v1
+        with:
+          args: --verbose
+      - uses: org/missing@v1
+      - uses: ./.github/actions/local
+      - run: echo "${{ steps.node.outputs.cache-hit }} ${{ steps.node.outputs.cache_hit }}"
+`))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	result, err := Validate(context.Background(), service, workflow)
+	if err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	want := []struct {
+		kind       string
+		suggestion string
+		line       int
+		column     int
+	}{
+		{kind: FindingUnknownInput, suggestion: "node-version", line: 9, column: 11},
+		{kind: FindingDeprecatedInput, line: 10, column: 11},
+		{kind: FindingMissingRequiredInput, line: 14, column: 9},
+		{kind: FindingActionUnavailable, line: 17, column: 15},
+		{kind: FindingUnknownOutput, suggestion: "cache-hit", line: 19, column: 60},
+	}
+	if len(result.Findings) != len(want) {
+		t.Fatalf("Validate() found %d problems, want %d: %+v", len(result.Findings), len(want), result.Findings)
+	}
+	for i, w := range want {
+		got := result.Findings[i]
+		if got.Kind != w.kind || got.Suggestion != w.suggestion || got.Line != w.line || got.Column != w.column {
+			t.Errorf("Findings[%d] = %+v, want kind %s, suggestion %q at %d:%d", i, got, w.kind, w.suggestion, w.line, w.column)
+		}
+	}
+	if !result.HasErrors() {
+		t.Errorf("HasErrors() = false, want true")
+	}
+	if result.ActionsChecked != 2 {
+		t.Errorf("ActionsChecked = %d, want 2", result.ActionsChecked)
+	}
+	// Every action is only fetched once, trying action.yml and action.yaml for tags and branches of the missing action
+	if requests != 6 {
+		t.Errorf("Validate() sent %d requests, want 6", requests)
+	}
+}
+
+func TestValidate_LocalActions(t *testing.T) {
+	workspace := t.TempDir()
+	actionDir := filepath.Join(workspace, ".github", "actions", "setup")
+	if err := os.MkdirAll(actionDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(actionDir, "action.yml"), []byte("name: Setup\ninputs:\n  version:\n    required: true\nruns:\n  using: composite\n  steps: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	service := newTestService(t, http.NotFoundHandler())
+
+	workflow, err := Parse([]byte(`on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: ./.github/actions/setup
+        with:
+          versoin: 1
+`))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	// Without a workspace, local actions are not checked
+	result, err := Validate(context.Background(), service, workflow)
+	if err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if len(result.Findings) != 0 || result.ActionsChecked != 0 {
+		t.Errorf("Validate() without workspace = %+v, want no findings", result)
+	}
+
+	result, err = Validate(github.WithWorkspace(context.Background(), workspace), service, workflow)
+	if err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if len(result.Findings) != 2 || result.Findings[0].Kind != FindingMissingRequiredInput || result.Findings[1].Suggestion != "version" {
+		t.Errorf("Validate() with workspace = %+v, want a missing and a misspelled input", result.Findings)
+	}
+}
+
+func TestSuggestName(t *testing.T) {
+	candidates := []string{"cache", "cache-dependency-path", "node-version", "node-version-file", "token"}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "node_version", want: "node-version"},
+		{name: "NodeVersion", want: "node-version"},
+		{name: "node-verison", want: "node-version"},
+		{name: "tokn", want: "token"},
+		{name: "registry-url", want: ""},
+		{name: "x", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestName(tt.name, candidates); got != tt.want {
+				t.Errorf("suggestName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+// Package workflow parses GitHub Actions workflow files (.github/workflows/*.yml).
+//
+// Workflows are decoded into typed jobs and steps which remember their position in the
+// file, so that findings about a workflow can point to the exact line and column.
+// See: https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-syntax
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a location in a workflow file. Lines and columns start at 1.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// positionOf returns the position of a YAML node.
+func positionOf(node *yaml.Node) Position {
+	return Position{Line: node.Line, Column: node.Column}
+}
+
+// Workflow is the typed representation of a workflow file.
+type Workflow struct {
+	Name string
+	// Events lists the names of the events triggering the workflow, e.g. "push" or "workflow_call".
+	Events []string
+	// WorkflowCall is the "on.workflow_call" trigger of reusable workflows, nil if the workflow cannot be called.
+	WorkflowCall *WorkflowCall
+	Env          map[string]string
+	// Jobs are in the order they appear in the file.
+	Jobs []*Job
+}
+
+// Job is a single job of a workflow. A job either runs Steps on a runner,
+// or calls the reusable workflow referenced by Uses.
+type Job struct {
+	ID    string
+	Name  string
+	Needs []string
+	Uses  string
+	With  map[string]string
+	Env   map[string]string
+	Steps []*Step
+
+	// StepOutputReferences are the references to step outputs in the job's expressions,
+	// e.g. "${{ steps.build.outputs.digest }}".
+	StepOutputReferences []StepOutputReference
+
+	// Position is the position of the job's ID.
+	Position Position
+	// UsesPosition is the position of the uses value, if set.
+	UsesPosition Position
+}
+
+// StepOutputReference is a reference to the output of a step, e.g. "steps.build.outputs.digest".
+type StepOutputReference struct {
+	StepID   string
+	Output   string
+	Position Position
+}
+
+// Step is a single step of a job, either running a command (Run) or an action (Uses).
+type Step struct {
+	ID   string
+	Name string
+	Uses string
+	Run  string
+	With map[string]string
+	Env  map[string]string
+
+	// Position is the position of the step's first key.
+	Position Position
+	// UsesPosition is the position of the uses value, if set.
+	UsesPosition Position
+	// WithPositions are the positions of the keys of With.
+	WithPositions map[string]Position
+}
+
+// Label returns a human-readable label for the step: its name, its ID, or the action it uses.
+func (s *Step) Label() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.ID != "":
+		return s.ID
+	case s.Uses != "":
+		return s.Uses
+	default:
+		return "run"
+	}
+}
+
+// WorkflowCall declares the interface of a reusable workflow.
+type WorkflowCall struct {
+	Inputs  map[string]WorkflowCallInput  `yaml:"inputs" json:"inputs,omitempty" jsonschema:"Inputs accepted by the workflow, keyed by input ID"`
+	Secrets map[string]WorkflowCallSecret `yaml:"secrets" json:"secrets,omitempty" jsonschema:"Secrets accepted by the workflow, keyed by secret ID"`
+	Outputs map[string]WorkflowCallOutput `yaml:"outputs" json:"outputs,omitempty" jsonschema:"Outputs set by the workflow, keyed by output ID"`
+}
+
+// WorkflowCallInput describes an input of a reusable workflow.
+type WorkflowCallInput struct {
+	Description string `yaml:"description" json:"description,omitempty"`
+	Type        string `yaml:"type" json:"type" jsonschema:"One of boolean, number or string"`
+	Required    bool   `yaml:"required" json:"required"`
+	Default     string `yaml:"default" json:"default,omitempty"`
+}
+
+// WorkflowCallSecret describes a secret of a reusable workflow.
+type WorkflowCallSecret struct {
+	Description string `yaml:"description" json:"description,omitempty"`
+	Required    bool   `yaml:"required" json:"required"`
+}
+
+// UnmarshalYAML decodes an input, accepting values of required which are not booleans (see decodeBool).
+func (i *WorkflowCallInput) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		Description string    `yaml:"description"`
+		Type        string    `yaml:"type"`
+		Required    yaml.Node `yaml:"required"`
+		Default     string    `yaml:"default"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	*i = WorkflowCallInput{
+		Description: raw.Description,
+		Type:        raw.Type,
+		Required:    decodeBool(&raw.Required),
+		Default:     raw.Default,
+	}
+	return nil
+}
+
+// UnmarshalYAML decodes a secret, accepting values of required which are not booleans (see decodeBool).
+func (s *WorkflowCallSecret) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		Description string    `yaml:"description"`
+		Required    yaml.Node `yaml:"required"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	*s = WorkflowCallSecret{
+		Description: raw.Description,
+		Required:    decodeBool(&raw.Required),
+	}
+	return nil
+}
+
+// WorkflowCallOutput describes an output of a reusable workflow.
+type WorkflowCallOutput struct {
+	Description string `yaml:"description" json:"description,omitempty"`
+	Value       string `yaml:"value" json:"value"`
+}
+
+// Parse parses the content of a workflow file.
+func Parse(data []byte) (*Workflow, error) {
+	var raw struct {
+		Name string    `yaml:"name"`
+		On   yaml.Node `yaml:"on"`
+		Env  yaml.Node `yaml:"env"`
+		Jobs yaml.Node `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
+	workflow := &Workflow{
+		Name: raw.Name,
+		Env:  decodeValues(&raw.Env),
+	}
+	if err := workflow.parseOn(&raw.On); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+	if err := workflow.parseJobs(&raw.Jobs); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+	return workflow, nil
+}
+
+// parseOn parses the triggers of a workflow, which are given as a single event,
+// a list of events, or a mapping of events to their configuration.
+func (w *Workflow) parseOn(node *yaml.Node) error {
+	switch node.Kind {
+	case 0:
+		// No triggers, e.g. when parsing a fragment of a workflow
+	case yaml.ScalarNode:
+		w.Events = []string{node.Value}
+	case yaml.SequenceNode:
+		if err := node.Decode(&w.Events); err != nil {
+			return err
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			event, config := node.Content[i], node.Content[i+1]
+			w.Events = append(w.Events, event.Value)
+			if event.Value != "workflow_call" {
+				continue
+			}
+			w.WorkflowCall = &WorkflowCall{}
+			if err := config.Decode(w.WorkflowCall); err != nil {
+				return fmt.Errorf("line %d: invalid workflow_call trigger: %w", config.Line, err)
+			}
+		}
+	default:
+		return fmt.Errorf("line %d: invalid value for on", node.Line)
+	}
+
+	// on.workflow_call without configuration declares a reusable workflow without inputs
+	if w.WorkflowCall == nil {
+		for _, event := range w.Events {
+			if event == "workflow_call" {
+				w.WorkflowCall = &WorkflowCall{}
+			}
+		}
+	}
+	return nil
+}
+
+// parseJobs parses the jobs mapping of a workflow, keeping the jobs in file order.
+func (w *Workflow) parseJobs(node *yaml.Node) error {
+	if node.Kind == 0 {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: jobs must be a mapping", node.Line)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		id, value := node.Content[i], node.Content[i+1]
+		var job Job
+		if err := value.Decode(&job); err != nil {
+			return fmt.Errorf("job %s: %w", id.Value, err)
+		}
+		job.ID = id.Value
+		job.Position = positionOf(id)
+		w.Jobs = append(w.Jobs, &job)
+	}
+	return nil
+}
+
+// UnmarshalYAML decodes a job, recording the positions of its nodes.
+// Needs is accepted both as a single job ID and as a list of job IDs.
+func (j *Job) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		Name  string    `yaml:"name"`
+		Needs yaml.Node `yaml:"needs"`
+		Uses  yaml.Node `yaml:"uses"`
+		With  yaml.Node `yaml:"with"`
+		Env   yaml.Node `yaml:"env"`
+		Steps []*Step   `yaml:"steps"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	var needs []string
+	switch raw.Needs.Kind {
+	case yaml.ScalarNode:
+		needs = []string{raw.Needs.Value}
+	case yaml.SequenceNode:
+		if err := raw.Needs.Decode(&needs); err != nil {
+			return err
+		}
+	}
+
+	*j = Job{
+		Name:                 raw.Name,
+		Needs:                needs,
+		Uses:                 raw.Uses.Value,
+		With:                 decodeValues(&raw.With),
+		Env:                  decodeValues(&raw.Env),
+		Steps:                raw.Steps,
+		StepOutputReferences: findStepOutputReferences(value),
+		UsesPosition:         positionOf(&raw.Uses),
+	}
+	return nil
+}
+
+// decodeValues decodes a mapping of names to values such as env or with, stringifying the values:
+// scalars of any type keep their text (e.g. "0" or "true"), and the rare lists and mappings are
+// kept as flow-style YAML. A mapping given as a whole by an expression, e.g.
+// "env: ${{ fromJSON(inputs.env) }}", cannot be known before the workflow runs and decodes to nil.
+func decodeValues(node *yaml.Node) map[string]string {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	values := make(map[string]string, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch {
+		case value.Kind == yaml.ScalarNode && value.Tag == "!!null":
+			values[key.Value] = ""
+		case value.Kind == yaml.ScalarNode:
+			values[key.Value] = value.Value
+		default:
+			flow := *value
+			flow.Style = yaml.FlowStyle
+			data, err := yaml.Marshal(&flow)
+			if err != nil {
+				continue
+			}
+			values[key.Value] = strings.TrimSpace(string(data))
+		}
+	}
+	return values
+}
+
+// decodeBool decodes a boolean such as required. Values which are not booleans, e.g. expressions
+// like "${{ inputs.strict }}" that are only known when the workflow runs, decode to false.
+func decodeBool(node *yaml.Node) bool {
+	var value bool
+	if node.Kind != yaml.ScalarNode || node.Decode(&value) != nil {
+		return false
+	}
+	return value
+}
+
+// stepOutputPattern matches references to step outputs in expressions.
+var stepOutputPattern = regexp.MustCompile(`\bsteps\.([A-Za-z_][A-Za-z0-9_-]*)\.outputs\.([A-Za-z_][A-Za-z0-9_-]*)`)
+
+// findStepOutputReferences returns the references to step outputs in all values below node, in document order.
+func findStepOutputReferences(node *yaml.Node) []StepOutputReference {
+	if node.Kind != yaml.ScalarNode {
+		var references []StepOutputReference
+		for _, child := range node.Content {
+			references = append(references, findStepOutputReferences(child)...)
+		}
+		return references
+	}
+
+	var references []StepOutputReference
+	for _, match := range stepOutputPattern.FindAllStringSubmatchIndex(node.Value, -1) {
+		references = append(references, StepOutputReference{
+			StepID:   node.Value[match[2]:match[3]],
+			Output:   node.Value[match[4]:match[5]],
+			Position: scalarOffsetPosition(node, match[0]),
+		})
+	}
+	return references
+}
+
+// scalarOffsetPosition returns the position of the byte at offset in the value of a scalar node.
+// The position is exact for plain scalars on a single line and for the lines of block scalars
+// (run: |). For other scalars, or where the indentation is not known, the column of the scalar is used.
+func scalarOffsetPosition(node *yaml.Node, offset int) Position {
+	position := positionOf(node)
+	switch {
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		// The content of block scalars starts on the line after the indicator
+		position.Line += 1 + strings.Count(node.Value[:offset], "\n")
+	case node.Style == 0 && !strings.Contains(node.Value, "\n"):
+		position.Column += offset
+	}
+	return position
+}
+
+// UnmarshalYAML decodes a step, recording the positions of its nodes.
+func (s *Step) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		ID   string    `yaml:"id"`
+		Name string    `yaml:"name"`
+		Uses yaml.Node `yaml:"uses"`
+		Run  string    `yaml:"run"`
+		With yaml.Node `yaml:"with"`
+		Env  yaml.Node `yaml:"env"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	*s = Step{
+		ID:           raw.ID,
+		Name:         raw.Name,
+		Uses:         raw.Uses.Value,
+		Run:          raw.Run,
+		With:         decodeValues(&raw.With),
+		Env:          decodeValues(&raw.Env),
+		Position:     positionOf(value),
+		UsesPosition: positionOf(&raw.Uses),
+	}
+
+	// Remember where each input is set, to report findings about it
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		if value.Content[i].Value != "with" || value.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+		with := value.Content[i+1]
+		s.WithPositions = make(map[string]Position, len(with.Content)/2)
+		for k := 0; k+1 < len(with.Content); k += 2 {
+			s.WithPositions[with.Content[k].Value] = positionOf(with.Content[k])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	data := []byte(`name: CI
+on:
+  push:
+    branches: [main]
+  pull_request:
+env:
+  GO_VERSION: 1.25
+jobs:
+  build:
+    name: Build
+    runs-on: ubuntu-latest
+    env:
+      CGO_ENABLED: 0
+    steps:
+      - uses: actions/checkout@v5
+      - name: Set up Go
+        id: setup
+        uses: actions/setup-go@v6
+        with:
+          go-version: ${{ env.GO_VERSION }}
+          cache: true
+      - run: go test ./...
+  release:
+    needs: build
+    uses: org/workflows/.github/workflows/release.yml@v2
+    with:
+      dry-run: false
+`)
+
+	workflow, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	if workflow.Name != "CI" {
+		t.Errorf("Name = %q, want %q", workflow.Name, "CI")
+	}
+	if want := []string{"push", "pull_request"}; !reflect.DeepEqual(workflow.Events, want) {
+		t.Errorf("Events = %v, want %v", workflow.Events, want)
+	}
+	if workflow.WorkflowCall != nil {
+		t.Errorf("WorkflowCall = %+v, want nil", workflow.WorkflowCall)
+	}
+	if got := workflow.Env["GO_VERSION"]; got != "1.25" {
+		t.Errorf("Env[GO_VERSION] = %q, want %q", got, "1.25")
+	}
+	if len(workflow.Jobs) != 2 || workflow.Jobs[0].ID != "build" || workflow.Jobs[1].ID != "release" {
+		t.Fatalf("Jobs = %+v, want build and release in file order", workflow.Jobs)
+	}
+
+	build := workflow.Jobs[0]
+	if build.Name != "Build" || build.Env["CGO_ENABLED"] != "0" || build.Position != (Position{Line: 9, Column: 3}) {
+		t.Errorf("Jobs[build] = %+v, want name, env and position of the job ID", build)
+	}
+	if len(build.Steps) != 3 {
+		t.Fatalf("len(Steps) = %d, want 3", len(build.Steps))
+	}
+	setup := build.Steps[1]
+	if setup.ID != "setup" || setup.Uses != "actions/setup-go@v6" || setup.With["cache"] != "true" {
+		t.Errorf("Steps[1] = %+v, want setup-go step with inputs", setup)
+	}
+	if setup.Position != (Position{Line: 16, Column: 9}) || setup.UsesPosition != (Position{Line: 18, Column: 15}) {
+		t.Errorf("Steps[1] position = %+v, uses position = %+v, want 16:9 and 18:15", setup.Position, setup.UsesPosition)
+	}
+	if got := setup.WithPositions["cache"]; got != (Position{Line: 21, Column: 11}) {
+		t.Errorf("Steps[1].WithPositions[cache] = %+v, want 21:11", got)
+	}
+	if got := build.Steps[2].Label(); got != "run" {
+		t.Errorf("Steps[2].Label() = %q, want %q", got, "run")
+	}
+
+	release := workflow.Jobs[1]
+	if !reflect.DeepEqual(release.Needs, []string{"build"}) || release.Uses != "org/workflows/.github/workflows/release.yml@v2" || release.With["dry-run"] != "false" {
+		t.Errorf("Jobs[release] = %+v, want needs, uses and with of a reusable workflow call", release)
+	}
+}
+
+func TestParse_WorkflowCall(t *testing.T) {
+	data := []byte(`on:
+  workflow_call:
+    inputs:
+      environment:
+        description: Target environment
+        type: string
+        required: true
+      debug:
+        type: boolean
+        default: false
+    secrets:
+      token:
+        required: true
+    outputs:
+      url:
+        description: Deployment URL
+        value: ${{ jobs.deploy.outputs.url }}
+jobs: {}
+`)
+
+	workflow, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	want := &WorkflowCall{
+		Inputs: map[string]WorkflowCallInput{
+			"environment": {Description: "Target environment", Type: "string", Required: true},
+			"debug":       {Type: "boolean", Default: "false"},
+		},
+		Secrets: map[string]WorkflowCallSecret{"token": {Required: true}},
+		Outputs: map[string]WorkflowCallOutput{"url": {Description: "Deployment URL", Value: "${{ jobs.deploy.outputs.url }}"}},
+	}
+	if !reflect.DeepEqual(workflow.WorkflowCall, want) {
+		t.Errorf("WorkflowCall = %+v, want %+v", workflow.WorkflowCall, want)
+	}
+}
+
+func TestParse_Expressions(t *testing.T) {
+	data := []byte(`on:
+  workflow_call:
+    inputs:
+      strict:
+        type: boolean
+        required: ${{ github.event_name == 'push' }}
+    secrets:
+      token:
+        required: ${{ true }}
+env: ${{ fromJSON(vars.WORKFLOW_ENV) }}
+jobs:
+  build:
+    env: ${{ fromJSON(inputs.env) }}
+    steps:
+      - uses: actions/checkout@v5
+        with:
+          fetch-depth: 0
+          lfs: true
+          sparse-checkout: ~
+          paths: [src, docs]
+        env: ${{ matrix.env }}
+`)
+
+	workflow, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	if workflow.Env != nil {
+		t.Errorf("Env = %v, want nil for a mapping given by an expression", workflow.Env)
+	}
+	if got := workflow.WorkflowCall.Inputs["strict"]; got.Required || got.Type != "boolean" {
+		t.Errorf("Inputs[strict] = %+v, want a boolean input which is not known to be required", got)
+	}
+	if got := workflow.WorkflowCall.Secrets["token"]; got.Required {
+		t.Errorf("Secrets[token] = %+v, want a secret which is not known to be required", got)
+	}
+
+	build := workflow.Jobs[0]
+	if build.Env != nil {
+		t.Errorf("Jobs[build].Env = %v, want nil for a mapping given by an expression", build.Env)
+	}
+	step := build.Steps[0]
+	if step.Env != nil {
+		t.Errorf("Steps[0].Env = %v, want nil for a mapping given by an expression", step.Env)
+	}
+	want := map[string]string{"fetch-depth": "0", "lfs": "true", "sparse-checkout": "", "paths": "[src, docs]"}
+	if !reflect.DeepEqual(step.With, want) {
+		t.Errorf("Steps[0].With = %v, want %v", step.With, want)
+	}
+}
+
+func TestParse_Events(t *testing.T) {
+	tests := []struct {
+		name             string
+		on               string
+		want             []string
+		wantWorkflowCall bool
+	}{
+		{name: "single event", on: "push", want: []string{"push"}},
+		{name: "list of events", on: "[push, workflow_call]", want: []string{"push", "workflow_call"}, wantWorkflowCall: true},
+		{name: "workflow_call without configuration", on: "{workflow_call: }", want: []string{"workflow_call"}, wantWorkflowCall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflow, err := Parse([]byte("on: " + tt.on + "\njobs: {}\n"))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(workflow.Events, tt.want) {
+				t.Errorf("Events = %v, want %v", workflow.Events, tt.want)
+			}
+			if got := workflow.WorkflowCall != nil; got != tt.wantWorkflowCall {
+				t.Errorf("WorkflowCall != nil = %v, want %v", got, tt.wantWorkflowCall)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "invalid YAML", data: "jobs: ["},
+		{name: "jobs is a list", data: "jobs: [build]"},
+		{name: "steps is a mapping", data: "jobs:\n  build:\n    steps: {uses: actions/checkout@v5}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); err == nil {
+				t.Errorf("Parse() expected error but got none")
+			}
+		})
+	}
+}
+
+func TestParse_StepOutputReferences(t *testing.T) {
+	data := []byte(`jobs:
+  build:
+    outputs:
+      digest: ${{ steps.build.outputs.digest }}
+    steps:
+      - id: build
+        uses: docker/build-push-action@v6
+      - run: |
+          echo "Built image"
+          echo "${{ steps.build.outputs.imageid }}"
+        env:
+          METADATA: "${{ steps.build.outputs.metadata }}"
+`)
+
+	workflow, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	want := []StepOutputReference{
+		{StepID: "build", Output: "digest", Position: Position{Line: 4, Column: 19}},
+		{StepID: "build", Output: "imageid", Position: Position{Line: 10, Column: 14}},
+		{StepID: "build", Output: "metadata", Position: Position{Line: 12, Column: 21}},
+	}
+	if got := workflow.Jobs[0].StepOutputReferences; !reflect.DeepEqual(got, want) {
+		t.Errorf("StepOutputReferences =\n%+v\nwant\n%+v", got, want)
+	}
+}